@@ -0,0 +1,71 @@
+package main
+
+import (
+	"math"
+
+	"goo/physics"
+)
+
+type Alien struct {
+	Object
+	AmmoType       ShotType
+	AmmoSpeed      float64
+	AmmoFreq       int
+	AmmoMaxTime    float64
+	WaitForRelease bool
+}
+
+func (a *Alien) Update(dt float64) {
+	if a.removed {
+		return
+	}
+
+	a.Active = false
+
+	a.Rotation += dt / (a.scene.Rand.Float64() * 50000)
+	a.X += math.Cos(a.Rotation)
+	a.Y += math.Sin(a.Rotation)
+
+	for i := 0; i < 2; i++ {
+		a.scene.Particles.NewParticle(physics.Particle{
+			R:    0xFFFFFF,
+			G:    0xFFFFFF,
+			B:    0xFFFFFF,
+			A:    0xFFFFFF,
+			Size: float64(1 + a.scene.Rand.Intn(2)),
+			Phys: physics.Phys{
+				X:           10 - a.scene.Rand.Float64()*20 + a.chunk.X + float64(a.chunk.SizeX)/4,
+				Y:           10 - a.scene.Rand.Float64()*20 + a.chunk.Y + float64(a.chunk.SizeY)/4,
+				VY:          (1 - a.scene.Rand.Float64()*6) * 5,
+				VX:          (1 - a.scene.Rand.Float64()*6) * 5,
+				Life:        a.scene.Rand.Float64() / 2,
+				Buoyancy:    1,
+				Restitution: 0.2,
+				Active:      true,
+			},
+		})
+	}
+	a.Object.Update(dt)
+
+	if !a.scene.rocket.removed && a.scene.Rand.Intn(100) > (100-a.AmmoFreq) {
+		if (a.WaitForRelease && a.scene.rocket.hasReleased) || !a.WaitForRelease {
+			s := &Shot{Type: a.AmmoType, Speed: a.AmmoSpeed, MaxTime: a.AmmoMaxTime}
+			s.Init(a.scene, a.chunk.X, a.chunk.Y, 2, "", ObjectShot)
+			a.scene.AddObject(s)
+		}
+	}
+
+}
+
+func (a *Alien) Hit(x, y int, objType ObjectType) {
+	switch a.collision(objType) {
+	case CollideExplode:
+		if !a.removed {
+			a.scene.gameMap.aliens++
+		}
+		a.Explode(int(a.X), int(a.Y))
+		a.removed = true
+	case CollideDamage:
+		a.Explode(x, y)
+	}
+}
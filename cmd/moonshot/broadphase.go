@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// broadphaseCell is the width and height of the cells of the broadphase
+// grid, in pixels.
+const broadphaseCell = 128
+
+// Broadphase finds the objects that may touch, so only those are checked
+// pixel by pixel. Objects are put in the cells of a uniform grid over the
+// screen that their bounding boxes cover, and objects sharing a cell with
+// overlapping boxes are pairs. Objects off the screen go in the nearest
+// cells.
+type Broadphase struct {
+	cells [][]int
+	boxes [][4]float64
+	seen  []int
+	found []int
+	pairs []int
+
+	// Counters of the last step, shown in the stats.
+	Objects    int
+	Candidates int
+	Pairs      int
+	Pixels     int
+	Hits       int
+	BroadTime  time.Duration
+	NarrowTime time.Duration
+}
+
+// cellRange returns the first and last of cells grid cells from v0 to v1.
+func cellRange(v0, v1 float64, cells int) (int, int) {
+	return cellAt(v0, cells), cellAt(v1, cells)
+}
+
+func cellAt(v float64, cells int) int {
+	return int(math.Max(0, math.Min(float64(cells-1), math.Floor(v/broadphaseCell))))
+}
+
+// Find returns the pairs of objects whose boxes overlap, as indexes into
+// objects, in the order the objects are in. Each pair is given both ways
+// round.
+func (b *Broadphase) Find(objects []Obj) []int {
+	cols := (screenWidth + broadphaseCell - 1) / broadphaseCell
+	rows := (screenHeight + broadphaseCell - 1) / broadphaseCell
+	if len(b.cells) != cols*rows {
+		b.cells = make([][]int, cols*rows)
+	}
+	for i := range b.cells {
+		b.cells[i] = b.cells[i][:0]
+	}
+	b.boxes = b.boxes[:0]
+	b.seen = b.seen[:0]
+	b.pairs = b.pairs[:0]
+	b.Objects, b.Candidates = 0, 0
+
+	for i, o := range objects {
+		x0, y0, x1, y1 := o.GetBounds()
+		b.boxes = append(b.boxes, [4]float64{x0, y0, x1, y1})
+		b.seen = append(b.seen, -1)
+		if o.IsRemoved() {
+			continue
+		}
+		b.Objects++
+		cx0, cx1 := cellRange(x0, x1, cols)
+		cy0, cy1 := cellRange(y0, y1, rows)
+		for cx := cx0; cx <= cx1; cx++ {
+			for cy := cy0; cy <= cy1; cy++ {
+				b.cells[cx*rows+cy] = append(b.cells[cx*rows+cy], i)
+			}
+		}
+	}
+
+	for i, o := range objects {
+		if o.IsRemoved() {
+			continue
+		}
+		box := b.boxes[i]
+		b.found = b.found[:0]
+		cx0, cx1 := cellRange(box[0], box[2], cols)
+		cy0, cy1 := cellRange(box[1], box[3], rows)
+		for cx := cx0; cx <= cx1; cx++ {
+			for cy := cy0; cy <= cy1; cy++ {
+				for _, j := range b.cells[cx*rows+cy] {
+					if j == i || b.seen[j] == i {
+						continue
+					}
+					b.seen[j] = i
+					b.Candidates++
+					other := b.boxes[j]
+					if box[0] <= other[2] && other[0] <= box[2] && box[1] <= other[3] && other[1] <= box[3] {
+						b.found = append(b.found, j)
+					}
+				}
+			}
+		}
+		sort.Ints(b.found)
+		for _, j := range b.found {
+			b.pairs = append(b.pairs, i, j)
+		}
+	}
+	b.Pairs = len(b.pairs) / 2
+	return b.pairs
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// box returns an object of w by h blocks at x, y, moved mx, my by the last
+// step.
+func box(x, y float64, w, h int, mx, my float64) *Object {
+	o := &Object{moveX: mx, moveY: my}
+	o.X, o.Y = x, y
+	o.chunk.SizeX, o.chunk.SizeY = w, h
+	return o
+}
+
+func removed(o *Object) *Object {
+	o.Remove()
+	return o
+}
+
+// allPairs is the pairs Find should return: every pair of objects, in order,
+// with overlapping boxes, as the pixels of every pair were checked before.
+func allPairs(objects []Obj) []int {
+	pairs := []int{}
+	for i, p1 := range objects {
+		if p1.IsRemoved() {
+			continue
+		}
+		for j, p := range objects {
+			if i == j || p.IsRemoved() {
+				continue
+			}
+			ax0, ay0, ax1, ay1 := p1.GetBounds()
+			bx0, by0, bx1, by1 := p.GetBounds()
+			if ax0 <= bx1 && bx0 <= ax1 && ay0 <= by1 && by0 <= ay1 {
+				pairs = append(pairs, i, j)
+			}
+		}
+	}
+	return pairs
+}
+
+func samePairs(a, b []int) bool {
+	return len(a) == 0 && len(b) == 0 || reflect.DeepEqual(a, b)
+}
+
+func TestBroadphaseFind(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []Obj
+		pairs   []int
+	}{
+		{
+			name:    "apart in one cell",
+			objects: []Obj{box(10, 10, 20, 20, 0, 0), box(60, 60, 20, 20, 0, 0)},
+			pairs:   []int{},
+		},
+		{
+			name:    "touching in one cell",
+			objects: []Obj{box(10, 10, 20, 20, 0, 0), box(30, 30, 20, 20, 0, 0)},
+			pairs:   []int{0, 1, 1, 0},
+		},
+		{
+			name:    "straddling a cell edge",
+			objects: []Obj{box(110, 10, 20, 20, 0, 0), box(129, 10, 20, 20, 0, 0)},
+			pairs:   []int{0, 1, 1, 0},
+		},
+		{
+			name:    "straddling four cells",
+			objects: []Obj{box(120, 120, 16, 16, 0, 0), box(130, 100, 10, 30, 0, 0), box(100, 130, 30, 10, 0, 0), box(200, 200, 10, 10, 0, 0)},
+			pairs:   []int{0, 1, 0, 2, 1, 0, 1, 2, 2, 0, 2, 1},
+		},
+		{
+			name:    "large",
+			objects: []Obj{box(5, 900, 10, 10, 0, 0), box(0, 0, 1000, 800, 0, 0), box(990, 790, 10, 10, 0, 0), box(1100, 10, 10, 10, 0, 0)},
+			pairs:   []int{1, 2, 2, 1},
+		},
+		{
+			name:    "moving across a cell edge",
+			objects: []Obj{box(100, 10, 10, 10, 0, 0), box(140, 10, 10, 10, 30, 0)},
+			pairs:   []int{0, 1, 1, 0},
+		},
+		{
+			name:    "moving across cells diagonally",
+			objects: []Obj{box(300, 300, 10, 10, 200, 200), box(200, 200, 10, 10, 0, 0), box(150, 250, 10, 10, 0, 0)},
+			pairs:   []int{0, 1, 0, 2, 1, 0, 2, 0},
+		},
+		{
+			name:    "off the screen",
+			objects: []Obj{box(-500, 2000, 10, 10, 0, 0), box(-495, 2005, 10, 10, 0, 0), box(-200, 2000, 10, 10, 0, 0)},
+			pairs:   []int{0, 1, 1, 0},
+		},
+		{
+			name:    "onto the screen",
+			objects: []Obj{box(5, 5, 10, 10, 50, 0), box(-40, 5, 10, 10, 0, 0)},
+			pairs:   []int{0, 1, 1, 0},
+		},
+		{
+			name:    "removed",
+			objects: []Obj{box(10, 10, 20, 20, 0, 0), removed(box(20, 20, 20, 20, 0, 0)), box(30, 30, 20, 20, 0, 0)},
+			pairs:   []int{0, 2, 2, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Broadphase{}
+			pairs := b.Find(tt.objects)
+			if !samePairs(pairs, tt.pairs) {
+				t.Errorf("Find = %v, want %v", pairs, tt.pairs)
+			}
+			if want := allPairs(tt.objects); !samePairs(pairs, want) {
+				t.Errorf("Find = %v, all pairs = %v", pairs, want)
+			}
+		})
+	}
+}
+
+func TestBroadphaseFindRandom(t *testing.T) {
+	b := Broadphase{}
+	for seed := int64(1); seed <= 20; seed++ {
+		t.Run(fmt.Sprint(seed), func(t *testing.T) {
+			r := rand.New(rand.NewSource(seed))
+			objects := []Obj{}
+			for i := 0; i < 100+r.Intn(100); i++ {
+				size := 1 + r.Intn(40)
+				if r.Intn(20) == 0 {
+					size = 200 + r.Intn(600)
+				}
+				o := box(
+					r.Float64()*(screenWidth+400)-200, r.Float64()*(screenHeight+400)-200,
+					size, 1+r.Intn(40),
+					r.Float64()*80-40, r.Float64()*80-40,
+				)
+				if r.Intn(10) == 0 {
+					o.Remove()
+				}
+				objects = append(objects, o)
+			}
+			// The same broadphase is used every step.
+			pairs := append([]int{}, b.Find(objects)...)
+			if want := allPairs(objects); !samePairs(pairs, want) {
+				t.Errorf("Find = %v, all pairs = %v", pairs, want)
+			}
+			if b.Pairs != len(pairs)/2 {
+				t.Errorf("Pairs = %d, want %d", b.Pairs, len(pairs)/2)
+			}
+		})
+	}
+}
+
+func TestGetBounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		o              *Object
+		x0, y0, x1, y1 float64
+	}{
+		{"still", box(10, 20, 5, 8, 0, 0), 9, 19, 16, 29},
+		{"moved right", box(10, 20, 5, 8, 30, 0), -21, 19, 16, 29},
+		{"moved left and up", box(10, 20, 5, 8, -30, 4), 9, 15, 46, 29},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x0, y0, x1, y1 := tt.o.GetBounds()
+			if x0 != tt.x0 || y0 != tt.y0 || x1 != tt.x1 || y1 != tt.y1 {
+				t.Errorf("GetBounds = %v, %v, %v, %v, want %v, %v, %v, %v", x0, y0, x1, y1, tt.x0, tt.y0, tt.x1, tt.y1)
+			}
+		})
+	}
+}
+
+// hitObject records its hits and is removed by them.
+type hitObject struct {
+	*Object
+	name string
+	hits *[]string
+}
+
+func (h *hitObject) Hit(x, y int, objType ObjectType) {
+	*h.hits = append(*h.hits, fmt.Sprintf("%s by %d", h.name, objType))
+	h.Remove()
+}
+
+func TestDetectCollisionsSkips(t *testing.T) {
+	hits := []string{}
+	solid := func(name string, x float64, objType ObjectType) *hitObject {
+		o := &Object{objType: objType}
+		o.X, o.Y = x, 10
+		// Border pixels are next to empty blocks, so leave a margin.
+		o.chunk.Init(12, 12, x, 10, 0, false)
+		for bx := 1; bx <= 10; bx++ {
+			for by := 1; by <= 10; by++ {
+				o.chunk.Add(bx, by, 1, 1, 1, 1)
+			}
+		}
+		o.BorderPixels = o.chunk.GetBorderPixels()
+		return &hitObject{Object: o, name: name, hits: &hits}
+	}
+	collisions, err := NewCollisions(map[string]string{
+		"satellite":  "explode",
+		"alien":      "explode",
+		"shot_alien": "ignore",
+		"alien_shot": "ignore",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		objects []Obj
+		hits    []string
+	}{
+		{
+			// The first object goes on hitting the rest after it is removed,
+			// but the rest don't hit each other once removed.
+			name: "removed by a hit",
+			objects: []Obj{
+				solid("a", 10, ObjectSatellite),
+				solid("b", 15, ObjectSatellite),
+				solid("c", 12, ObjectSatellite),
+			},
+			hits: []string{
+				fmt.Sprint("b by ", ObjectSatellite), fmt.Sprint("a by ", ObjectSatellite),
+				fmt.Sprint("c by ", ObjectSatellite), fmt.Sprint("a by ", ObjectSatellite),
+			},
+		},
+		{
+			name: "removed before",
+			objects: []Obj{
+				removed(solid("a", 10, ObjectSatellite).Object),
+				solid("b", 15, ObjectSatellite),
+				solid("c", 12, ObjectSatellite),
+			},
+			hits: []string{
+				fmt.Sprint("c by ", ObjectSatellite), fmt.Sprint("b by ", ObjectSatellite),
+			},
+		},
+		{
+			name: "ignored both ways",
+			objects: []Obj{
+				solid("a", 10, ObjectShot),
+				solid("b", 15, ObjectAlien),
+			},
+			hits: []string{},
+		},
+		{
+			name: "apart",
+			objects: []Obj{
+				solid("a", 10, ObjectSatellite),
+				solid("b", 21, ObjectSatellite),
+			},
+			hits: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits = []string{}
+			s := &Scene{objects: tt.objects, collisions: collisions}
+			s.DetectCollisions(1000.0 / 60)
+			if !reflect.DeepEqual(hits, tt.hits) {
+				t.Errorf("hits = %v, want %v", hits, tt.hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Collision is what happens to an object when it hits another.
+type Collision string
+
+const (
+	// CollideIgnore passes through the other object.
+	CollideIgnore Collision = "ignore"
+	// CollideExplode blows the object up and removes it.
+	CollideExplode Collision = "explode"
+	// CollideDamage knocks the blocks around the point hit out of the
+	// object.
+	CollideDamage Collision = "damage"
+	// CollideLand lands the rocket on the moon if it can, and explodes it
+	// otherwise.
+	CollideLand Collision = "land"
+)
+
+// objectNames are the names of the object types in collision matrices.
+var objectNames = map[string]ObjectType{
+	"rocket":    ObjectRocket,
+	"moon":      ObjectMoon,
+	"satellite": ObjectSatellite,
+	"alien":     ObjectAlien,
+	"shot":      ObjectShot,
+	"world":     ObjectWorld,
+	"debris":    ObjectDebris,
+}
+
+// Collisions is the collision matrix: what happens to an object of one
+// type when it hits an object of another. It is read from entries such as
+// "shot_alien": "ignore", for a shot hitting an alien, and "shot":
+// "explode", for a shot hitting anything without an entry of its own.
+// Pairs without either entry are ignored.
+type Collisions struct {
+	pairs    map[[2]ObjectType]Collision
+	defaults map[ObjectType]Collision
+}
+
+// NewCollisions builds the collision matrix from layers of entries, each
+// overriding the one before, e.g. the config and then the level.
+func NewCollisions(layers ...map[string]string) (Collisions, error) {
+	c := Collisions{
+		pairs:    map[[2]ObjectType]Collision{},
+		defaults: map[ObjectType]Collision{},
+	}
+	for _, entries := range layers {
+		if errs := c.setAll(entries); len(errs) > 0 {
+			return c, errs[0]
+		}
+	}
+	return c, nil
+}
+
+// CheckCollisions returns every problem with the entries of a collision
+// matrix.
+func CheckCollisions(entries map[string]string) []error {
+	c := Collisions{
+		pairs:    map[[2]ObjectType]Collision{},
+		defaults: map[ObjectType]Collision{},
+	}
+	return c.setAll(entries)
+}
+
+func (c *Collisions) setAll(entries map[string]string) []error {
+	errs := []error{}
+	for _, k := range sortedKeys(entries) {
+		if err := c.set(k, entries[k]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (c *Collisions) set(key, value string) error {
+	col := Collision(value)
+	switch col {
+	case CollideIgnore, CollideExplode, CollideDamage, CollideLand:
+	default:
+		return fmt.Errorf("%s: unknown collision %q, expected ignore, explode, damage or land", key, value)
+	}
+
+	names := strings.Split(key, "_")
+	types := []ObjectType{}
+	for _, n := range names {
+		t, ok := objectNames[strings.ToLower(n)]
+		if !ok {
+			return fmt.Errorf("%s: unknown object type %q, expected one of %s", key, n, strings.Join(sortedKeys(objectNames), ", "))
+		}
+		types = append(types, t)
+	}
+	if col == CollideLand && (len(types) != 2 || types[0] != ObjectRocket || types[1] != ObjectMoon) {
+		return fmt.Errorf("%s: only the rocket can land, on the moon", key)
+	}
+
+	switch len(types) {
+	case 1:
+		c.defaults[types[0]] = col
+	case 2:
+		c.pairs[[2]ObjectType{types[0], types[1]}] = col
+	default:
+		return fmt.Errorf("%s: expected an object type or two joined by _", key)
+	}
+	return nil
+}
+
+// Response returns what happens to an object of type t when it hits one of
+// type other.
+func (c Collisions) Response(t, other ObjectType) Collision {
+	if col, ok := c.pairs[[2]ObjectType{t, other}]; ok {
+		return col
+	}
+	if col, ok := c.defaults[t]; ok {
+		return col
+	}
+	return CollideIgnore
+}
+
+// Collides returns true if anything happens to either of a pair of objects
+// of types a and b when they touch.
+func (c Collisions) Collides(a, b ObjectType) bool {
+	return c.Response(a, b) != CollideIgnore || c.Response(b, a) != CollideIgnore
+}
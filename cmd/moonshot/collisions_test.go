@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goo"
+)
+
+var objectTypes = []ObjectType{
+	ObjectRocket, ObjectMoon, ObjectSatellite, ObjectAlien,
+	ObjectShot, ObjectBackground, ObjectWorld, ObjectDebris,
+}
+
+// oldResponse is what the Hit methods did before the collision matrix, for
+// an object of type t hitting one of type other.
+func oldResponse(t, other ObjectType, alienCanBeHitByDebris bool) Collision {
+	switch t {
+	case ObjectRocket:
+		if other == ObjectMoon {
+			return CollideLand
+		}
+		return CollideExplode
+	case ObjectMoon:
+		if other == ObjectSatellite || other == ObjectAlien || other == ObjectDebris {
+			return CollideIgnore
+		}
+		return CollideDamage
+	case ObjectSatellite:
+		if other == ObjectMoon {
+			return CollideIgnore
+		}
+		return CollideExplode
+	case ObjectAlien:
+		if other == ObjectRocket || other == ObjectDebris && alienCanBeHitByDebris {
+			return CollideExplode
+		}
+		return CollideIgnore
+	case ObjectShot:
+		if other == ObjectAlien || other == ObjectShot {
+			return CollideIgnore
+		}
+		return CollideExplode
+	case ObjectDebris:
+		if other == ObjectMoon || other == ObjectDebris {
+			return CollideIgnore
+		}
+		return CollideDamage
+	}
+	return CollideIgnore
+}
+
+func TestDefaultCollisions(t *testing.T) {
+	var conf Config
+	if err := conf.decode(bytes.NewReader(goo.DefaultConfig)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		level                 string
+		alienCanBeHitByDebris bool
+	}{
+		{"level01.json", false},
+		{"level02.json", false},
+		{"level03.json", true},
+		{"level04.json", true},
+		{"level05.json", false},
+		{"level06.json", false},
+		{"level07.json", false},
+		{"level08.json", true},
+		{"level09.json", false},
+		{"level10.json", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("../../assets/levels", tt.level))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var l Level
+			if err := json.Unmarshal(data, &l); err != nil {
+				t.Fatal(err)
+			}
+			c, err := NewCollisions(conf.Collisions, l.Collisions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, a := range objectTypes {
+				for _, b := range objectTypes {
+					want := oldResponse(a, b, tt.alienCanBeHitByDebris)
+					if got := c.Response(a, b); got != want {
+						t.Errorf("Response(%d, %d) = %s, want %s", a, b, got, want)
+					}
+					wantCollides := want != CollideIgnore || oldResponse(b, a, tt.alienCanBeHitByDebris) != CollideIgnore
+					if got := c.Collides(a, b); got != wantCollides {
+						t.Errorf("Collides(%d, %d) = %v, want %v", a, b, got, wantCollides)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewCollisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		layers    []map[string]string
+		t, other  ObjectType
+		collision Collision
+		err       string
+	}{
+		{
+			name:      "nothing",
+			t:         ObjectShot,
+			other:     ObjectAlien,
+			collision: CollideIgnore,
+		},
+		{
+			name:      "default",
+			layers:    []map[string]string{{"shot": "explode"}},
+			t:         ObjectShot,
+			other:     ObjectAlien,
+			collision: CollideExplode,
+		},
+		{
+			name:      "pair over default",
+			layers:    []map[string]string{{"shot": "explode", "shot_alien": "ignore"}},
+			t:         ObjectShot,
+			other:     ObjectAlien,
+			collision: CollideIgnore,
+		},
+		{
+			name:      "other way round",
+			layers:    []map[string]string{{"shot": "explode", "alien_shot": "ignore"}},
+			t:         ObjectShot,
+			other:     ObjectAlien,
+			collision: CollideExplode,
+		},
+		{
+			name:      "level over config",
+			layers:    []map[string]string{{"alien": "ignore"}, {"alien_debris": "explode"}},
+			t:         ObjectAlien,
+			other:     ObjectDebris,
+			collision: CollideExplode,
+		},
+		{
+			name:      "level default under config pair",
+			layers:    []map[string]string{{"alien_debris": "ignore"}, {"alien": "explode"}},
+			t:         ObjectAlien,
+			other:     ObjectDebris,
+			collision: CollideIgnore,
+		},
+		{
+			name:      "any case",
+			layers:    []map[string]string{{"Rocket_MOON": "land"}},
+			t:         ObjectRocket,
+			other:     ObjectMoon,
+			collision: CollideLand,
+		},
+		{
+			name:   "unknown collision",
+			layers: []map[string]string{{"shot": "bounce"}},
+			err:    `shot: unknown collision "bounce", expected ignore, explode, damage or land`,
+		},
+		{
+			name:   "unknown type",
+			layers: []map[string]string{{"shot_ufo": "ignore"}},
+			err:    `shot_ufo: unknown object type "ufo", expected one of alien, debris, moon, rocket, satellite, shot, world`,
+		},
+		{
+			name:   "three types",
+			layers: []map[string]string{{"shot_alien_moon": "ignore"}},
+			err:    "shot_alien_moon: expected an object type or two joined by _",
+		},
+		{
+			name:   "error in a later layer",
+			layers: []map[string]string{{"shot": "explode"}, {"alien": "vanish"}},
+			err:    `alien: unknown collision "vanish", expected ignore, explode, damage or land`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCollisions(tt.layers...)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("NewCollisions error = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Response(tt.t, tt.other); got != tt.collision {
+				t.Errorf("Response = %s, want %s", got, tt.collision)
+			}
+		})
+	}
+}
+
+func TestLandOnlyRocketOnMoon(t *testing.T) {
+	for name := range objectNames {
+		for other := range objectNames {
+			for _, key := range []string{name, name + "_" + other} {
+				_, err := NewCollisions(map[string]string{key: "land"})
+				if ok := key == "rocket_moon"; ok != (err == nil) {
+					t.Errorf("land for %s: error = %v", key, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckCollisions(t *testing.T) {
+	errs := CheckCollisions(map[string]string{
+		"shot":        "explode",
+		"moon_rocket": "land",
+		"alien":       "vanish",
+		"ufo":         "ignore",
+	})
+	got := []string{}
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		`alien: unknown collision "vanish", expected ignore, explode, damage or land`,
+		"moon_rocket: only the rocket can land, on the moon",
+		fmt.Sprintf(`ufo: unknown object type "ufo", expected one of %s`, "alien, debris, moon, rocket, satellite, shot, world"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckCollisions = %q, want %q", got, want)
+	}
+	if errs := CheckCollisions(map[string]string{"rocket_moon": "land"}); len(errs) != 0 {
+		t.Errorf("CheckCollisions = %v, want none", errs)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"goo"
+)
+
+// envPrefix is the prefix of environment variables that override config
+// values, e.g. MOONSHOT_KEYLOADFUEL or MOONSHOT_COLORS_FUEL_A.
+const envPrefix = "MOONSHOT_"
+
+type Config struct {
+	KeyLoadFuel   string            `json:"keyLoadFuel"`
+	KeyDumpFuel   string            `json:"keyDumpFuel"`
+	KeyRelease    string            `json:"keyRelease"`
+	KeyRespawn    string            `json:"keyRespawn"`
+	KeyDebugInfo  string            `json:"keyDebugInfo"`
+	KeyMenu       string            `json:"keyMenu"`
+	KeyPause      string            `json:"keyPause"`
+	KeyWireframe  string            `json:"keyWireframe"`
+	KeyMenuUp     string            `json:"keyMenuUp"`
+	KeyMenuDown   string            `json:"keyMenuDown"`
+	KeyMenuSelect string            `json:"keyMenuSelect"`
+	KeyThrust     string            `json:"keyThrust"`
+	KeySteerLeft  string            `json:"keySteerLeft"`
+	KeySteerRight string            `json:"keySteerRight"`
+	FlightMode    bool              `json:"flightMode"`
+	Levels        string            `json:"levels"`
+	Packs         []string          `json:"packs"`
+	Assets        map[string]string `json:"assets"`
+	Sounds        map[string]string `json:"sounds"`
+	Shaders       map[string]string `json:"shaders"`
+	Colors        map[string]Color  `json:"colors"`
+	Collisions    map[string]string `json:"collisions"`
+}
+
+type Color struct {
+	R float32 `json:"r"`
+	G float32 `json:"g"`
+	B float32 `json:"b"`
+	A float32 `json:"a"`
+}
+
+// UserConfigFile returns the path of the user config file,
+// $XDG_CONFIG_HOME/moonshot/config.json on Linux.
+func UserConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "moonshot", "config.json")
+}
+
+// LoadConfiguration merges the config layers, each overriding the one
+// before: the built-in defaults, the configs of the resource packs, the
+// user file, MOONSHOT_* environment variables and last the path=value
+// settings from the command line. A missing user file is skipped unless
+// required is set. The packs in the config are returned open, and must be
+// closed with ClosePacks.
+func LoadConfiguration(userFile string, required bool, sets []string) (Config, []*Pack, error) {
+	conf, err := loadConfig(nil, userFile, required, sets)
+	if err != nil || len(conf.Packs) == 0 {
+		return conf, nil, err
+	}
+
+	packs, err := OpenPacks(conf.Packs)
+	if err != nil {
+		return conf, nil, err
+	}
+	conf, err = loadConfig(packs, userFile, required, sets)
+	if err != nil {
+		ClosePacks(packs)
+		return conf, nil, err
+	}
+	return conf, packs, nil
+}
+
+func loadConfig(packs []*Pack, userFile string, required bool, sets []string) (Config, error) {
+	var conf Config
+	if err := conf.decode(bytes.NewReader(goo.DefaultConfig)); err != nil {
+		return conf, fmt.Errorf("built-in config: %v", err)
+	}
+
+	// Packs can't add packs.
+	builtin := conf.Packs
+	for _, p := range packs {
+		if err := p.decodeConfig(&conf); err != nil {
+			return conf, err
+		}
+	}
+	conf.Packs = builtin
+
+	if userFile != "" {
+		f, err := os.Open(userFile)
+		if err == nil {
+			err = conf.decode(f)
+			f.Close()
+			if err != nil {
+				return conf, fmt.Errorf("%s: %v", userFile, err)
+			}
+		} else if required || !os.IsNotExist(err) {
+			return conf, err
+		}
+	}
+
+	if err := conf.LoadEnv(os.Environ()); err != nil {
+		return conf, err
+	}
+
+	for _, s := range sets {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			return conf, fmt.Errorf("%s: expected path=value", s)
+		}
+		if err := conf.Set(kv[0], kv[1]); err != nil {
+			return conf, err
+		}
+	}
+	return conf, nil
+}
+
+// decode reads JSON on top of the config. Values in the JSON replace the
+// current ones, while entries in the maps are added to or replaced in the
+// current maps.
+func (c *Config) decode(f io.Reader) error {
+	return json.NewDecoder(f).Decode(c)
+}
+
+// LoadEnv applies the MOONSHOT_* variables in environ. The name after the
+// prefix is the config path in upper case with dots as underscores.
+// Variables that are not config values are logged and ignored, "config
+// validate" reports them.
+func (c *Config) LoadEnv(environ []string) error {
+	for _, kv := range envVars(environ) {
+		path, ok := c.envPath(strings.TrimPrefix(kv[0], envPrefix))
+		if !ok {
+			log.Printf("Ignoring %s: unknown config value", kv[0])
+			continue
+		}
+		if err := c.Set(path, kv[1]); err != nil {
+			return fmt.Errorf("%s: %v", kv[0], err)
+		}
+	}
+	return nil
+}
+
+// envVars returns the names and values of the MOONSHOT_* variables in
+// environ.
+func envVars(environ []string) [][2]string {
+	vars := [][2]string{}
+	for _, e := range environ {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) == 2 && strings.HasPrefix(kv[0], envPrefix) {
+			vars = append(vars, [2]string{kv[0], kv[1]})
+		}
+	}
+	return vars
+}
+
+// envPath turns the name of an environment variable into a config path.
+// Map keys may contain underscores, so only the field name, and for
+// colors the channel, are split off. Variable names are in upper case, so
+// keys are lowered and new ones are added in lower case.
+func (c *Config) envPath(name string) (string, bool) {
+	t := reflect.TypeOf(*c)
+	for i := 0; i < t.NumField(); i++ {
+		tag := jsonName(t.Field(i))
+		if strings.EqualFold(name, tag) {
+			return tag, true
+		}
+		if len(name) <= len(tag)+1 || !strings.EqualFold(name[:len(tag)+1], tag+"_") {
+			continue
+		}
+		key := strings.ToLower(name[len(tag)+1:])
+		if t.Field(i).Type == reflect.TypeOf(map[string]Color{}) {
+			n := strings.LastIndex(key, "_")
+			if n < 0 {
+				return "", false
+			}
+			key = key[:n] + "." + key[n+1:]
+		}
+		return tag + "." + key, true
+	}
+	return "", false
+}
+
+// Set sets the value at path, e.g. "keyLoadFuel", "assets.rocket" or
+// "colors.fuel.a". Names are matched without regard to case, and new map
+// keys are added as given. Lists such as "packs" are set from comma
+// separated values.
+func (c *Config) Set(path, value string) error {
+	parts := strings.Split(path, ".")
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !strings.EqualFold(parts[0], jsonName(t.Field(i))) {
+			continue
+		}
+		f := v.Field(i)
+
+		switch f.Kind() {
+		case reflect.String:
+			if len(parts) != 1 {
+				break
+			}
+			f.SetString(value)
+			return nil
+		case reflect.Bool:
+			if len(parts) != 1 {
+				break
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			f.SetBool(b)
+			return nil
+		case reflect.Slice:
+			if len(parts) != 1 {
+				break
+			}
+			list := []string{}
+			for _, v := range strings.Split(value, ",") {
+				if v != "" {
+					list = append(list, v)
+				}
+			}
+			f.Set(reflect.ValueOf(list))
+			return nil
+		case reflect.Map:
+			if len(parts) < 2 {
+				break
+			}
+			if f.IsNil() {
+				f.Set(reflect.MakeMap(f.Type()))
+			}
+			key := reflect.ValueOf(mapKey(f, parts[1]))
+
+			if f.Type().Elem().Kind() == reflect.String && len(parts) == 2 {
+				f.SetMapIndex(key, reflect.ValueOf(value))
+				return nil
+			}
+			if col, ok := f.Interface().(map[string]Color); ok && len(parts) == 3 {
+				cv, err := strconv.ParseFloat(value, 32)
+				if err != nil {
+					return fmt.Errorf("%s: %v", path, err)
+				}
+				color := col[key.String()]
+				switch strings.ToLower(parts[2]) {
+				case "r":
+					color.R = float32(cv)
+				case "g":
+					color.G = float32(cv)
+				case "b":
+					color.B = float32(cv)
+				case "a":
+					color.A = float32(cv)
+				default:
+					return fmt.Errorf("%s: unknown color channel %q", path, parts[2])
+				}
+				col[key.String()] = color
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: not a config value", path)
+	}
+	return fmt.Errorf("%s: unknown config value", path)
+}
+
+// mapKey returns the existing key of the map that matches key without
+// regard to case, or key as it is if there is none.
+func mapKey(m reflect.Value, key string) string {
+	for _, k := range m.MapKeys() {
+		if strings.EqualFold(k.String(), key) {
+			return k.String()
+		}
+	}
+	return key
+}
+
+func jsonName(f reflect.StructField) string {
+	return strings.Split(f.Tag.Get("json"), ",")[0]
+}
+
+// Print writes the config as indented JSON.
+func (c *Config) Print() error {
+	b, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(string(b))
+	return err
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigSetMapKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    bool
+		path   string
+		value  string
+		assets map[string]string
+		colors map[string]Color
+	}{
+		{
+			name:   "new key",
+			path:   "assets.myRock",
+			value:  "rock.png",
+			assets: map[string]string{"rocket": "rocket.png", "myRock": "rock.png"},
+		},
+		{
+			name:   "existing key",
+			path:   "ASSETS.Rocket",
+			value:  "other.png",
+			assets: map[string]string{"rocket": "other.png"},
+		},
+		{
+			name:   "new color",
+			path:   "colors.darkSky.b",
+			value:  "0.5",
+			assets: map[string]string{"rocket": "rocket.png"},
+			colors: map[string]Color{"fuel": {R: 1}, "darkSky": {B: 0.5}},
+		},
+		{
+			name:   "existing color",
+			path:   "colors.FUEL.G",
+			value:  "0.5",
+			assets: map[string]string{"rocket": "rocket.png"},
+			colors: map[string]Color{"fuel": {R: 1, G: 0.5}},
+		},
+		{
+			name:   "new key from the environment",
+			env:    true,
+			path:   "MOONSHOT_ASSETS_MY_ROCK",
+			value:  "rock.png",
+			assets: map[string]string{"rocket": "rocket.png", "my_rock": "rock.png"},
+		},
+		{
+			name:   "existing key from the environment",
+			env:    true,
+			path:   "MOONSHOT_ASSETS_ROCKET",
+			value:  "other.png",
+			assets: map[string]string{"rocket": "other.png"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{
+				Assets: map[string]string{"rocket": "rocket.png"},
+				Colors: map[string]Color{"fuel": {R: 1}},
+			}
+			var err error
+			if tt.env {
+				err = c.LoadEnv([]string{tt.path + "=" + tt.value})
+			} else {
+				err = c.Set(tt.path, tt.value)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(c.Assets, tt.assets) {
+				t.Errorf("assets = %v, want %v", c.Assets, tt.assets)
+			}
+			if tt.colors != nil && !reflect.DeepEqual(c.Colors, tt.colors) {
+				t.Errorf("colors = %v, want %v", c.Colors, tt.colors)
+			}
+		})
+	}
+}
+
+func TestLoadEnvUnknown(t *testing.T) {
+	c := Config{Assets: map[string]string{"rocket": "rocket.png"}}
+	environ := []string{
+		"HOME=/home/moon",
+		"MOONSHOT_NOSUCHTHING=1",
+		"MOONSHOT_ASSETS_ROCKET=other.png",
+	}
+	if err := c.LoadEnv(environ); err != nil {
+		t.Fatalf("LoadEnv = %v, want unknown variables ignored", err)
+	}
+	if c.Assets["rocket"] != "other.png" {
+		t.Errorf("assets.rocket = %q, want other.png", c.Assets["rocket"])
+	}
+	if err := c.LoadEnv([]string{"MOONSHOT_COLORS_FUEL_A=bright"}); err == nil {
+		t.Errorf("LoadEnv of a bad value gave no error")
+	}
+
+	errs := ValidateEnv(append(environ, "MOONSHOT_COLORS_FUEL_A=bright"))
+	got := []string{}
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"MOONSHOT_NOSUCHTHING: unknown config value",
+		`MOONSHOT_COLORS_FUEL_A: colors.fuel.a: strconv.ParseFloat: parsing "bright": invalid syntax`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateEnv = %q, want %q", got, want)
+	}
+}
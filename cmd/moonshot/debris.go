@@ -0,0 +1,40 @@
+package main
+
+import (
+	"math"
+)
+
+type Debris struct {
+	Object
+	released bool
+	boost    float64
+	origX    float64
+	origY    float64
+}
+
+func (s *Debris) Update(dt float64) {
+	if s.removed {
+		return
+	}
+
+	s.chunk.RotationDeg += float32(dt / s.origX)
+	s.Kinematic = true
+	s.Rotation += dt / 1000
+	s.MoveTo(
+		(s.scene.moon.chunk.X+float64(s.scene.moon.chunk.SizeX/2))+s.origX*math.Cos(s.Rotation),
+		(s.scene.moon.chunk.Y+float64(s.scene.moon.chunk.SizeY/2))+s.origY*math.Sin(s.Rotation),
+		dt,
+	)
+
+	s.Object.Update(dt)
+}
+
+func (s *Debris) Hit(x, y int, objType ObjectType) {
+	switch s.collision(objType) {
+	case CollideExplode:
+		s.removed = true
+		s.Explode(int(s.X), int(s.Y))
+	case CollideDamage:
+		s.Explode(x, y)
+	}
+}
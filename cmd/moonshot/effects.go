@@ -0,0 +1,123 @@
+package main
+
+import (
+	"math"
+
+	"goo/physics"
+)
+
+// burst returns a velocity in a random direction, most often well below
+// speed.
+func (s *Scene) burst(speed float64) (float64, float64) {
+	a := s.Rand.Float64() * 2 * math.Pi
+	v := s.Rand.Float64() * s.Rand.Float64() * speed
+	return math.Cos(a) * v, math.Sin(a) * v
+}
+
+func (s *Scene) Smoke(x, y, power float64) {
+	for i := 0; i < 50; i++ {
+		// smoke
+		color := s.Rand.Float32() * 0xFFFF
+		s.Particles.NewParticle(physics.Particle{
+			R:    color,
+			G:    color,
+			B:    color,
+			A:    color,
+			Size: float64(1 + s.Rand.Intn(2)),
+			Phys: physics.Phys{
+				X:        x,
+				Y:        y,
+				VY:       (power/2 - s.Rand.Float64()*power) * 2,
+				VX:       (power/2 - s.Rand.Float64()*power) * 2,
+				Life:     s.Rand.Float64() * 3,
+				Buoyancy: 1.2,
+				Drag:     1,
+				Active:   true,
+			},
+		})
+	}
+}
+
+func (s *Scene) Explode(x, y, power float64) {
+	s.World.Explode(int(x), int(y), int(power))
+	// Fire flies out faster the more power.
+	speed := power * power / 8
+	for i := 0; i < int(power)*50; i++ {
+		// smoke
+		color := s.Rand.Float32() * 0xFFFF
+
+		vx, vy := s.burst(power / 3)
+		s.Particles.NewParticle(physics.Particle{
+			R:    color,
+			G:    color,
+			B:    color,
+			A:    color,
+			Size: float64(1 + s.Rand.Intn(2)),
+			Phys: physics.Phys{
+				X:        x,
+				Y:        y,
+				VX:       vx,
+				VY:       vy,
+				Life:     s.Rand.Float64() * 2,
+				Buoyancy: 1.2,
+				Drag:     1,
+				Active:   true,
+			},
+		})
+		// Fire
+		cr := float32(s.Rand.Intn(0xFFFFFF))
+		cg := float32(s.Rand.Intn(0x33555))
+		cb := float32(0)
+		ca := float32(0xFFF + s.Rand.Intn(0xFFFFFF))
+
+		// Some random exploding parts.
+		life := s.Rand.Float64()
+		expHit := 0
+		if power > 3 {
+			if s.Rand.Intn(100) > 97 {
+				life += 2
+				expHit = 2 + s.Rand.Intn(3)
+			}
+		}
+
+		vx, vy = s.burst(speed)
+		s.Particles.NewParticle(physics.Particle{
+			R:    cr * 2,
+			G:    cg,
+			B:    cb,
+			A:    ca,
+			Size: float64(1 + s.Rand.Intn(3)),
+			Phys: physics.Phys{
+				ExplodeOnHit: expHit,
+				X:            x,
+				Y:            y,
+				VX:           vx,
+				VY:           vy,
+				Life:         life,
+				Buoyancy:     0.8,
+				Drag:         1,
+				Restitution:  0.5,
+				Friction:     0.5,
+				Active:       true,
+			},
+		})
+	}
+}
+
+func (s *Scene) Star() {
+	s.Particles.NewParticle(physics.Particle{
+		R:    0xFFFFFF,
+		G:    0xFFFFFF,
+		B:    0xFFFFFF,
+		A:    0xFFFF,
+		Z:    0,
+		Size: s.Rand.Float64() * 5,
+		Phys: physics.Phys{
+			X:        screenWidth * s.Rand.Float64(),
+			Y:        screenHeight - s.Rand.Float64()*screenHeight/3,
+			Life:     3 + s.Rand.Float64()*2,
+			Buoyancy: 1,
+			Active:   true,
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"goo/gfx"
+	"goo/sound"
+)
+
+// Game owns what is shared by the whole program: the renderer, the
+// configuration, the levels, the player profile, sound, the menu, stats
+// and the scene being played.
+type Game struct {
+	renderer gfx.Renderer
+	conf     Config
+	levels   []Level
+	profile  *Profile
+	sound    *sound.Sound
+	menu     *Menu
+	stats    *Stats
+	scene    *Scene
+
+	// reloader reloads files while the game runs, it is nil in headless
+	// mode.
+	reloader *Reloader
+
+	// errText is shown on top of the game, e.g. when a reload fails.
+	errText string
+}
+
+// NewGame creates a game drawn with r, with a scene seeded with seed. The
+// renderer must be initialized first, since fonts are loaded through it.
+func NewGame(r gfx.Renderer, conf Config, levels []Level, profile *Profile, seed int64) *Game {
+	g := &Game{
+		renderer: r,
+		conf:     conf,
+		levels:   levels,
+		profile:  profile,
+		sound:    &sound.Sound{},
+		menu:     &Menu{},
+		stats:    &Stats{},
+	}
+	g.stats.Init(g)
+	g.menu.Init(g)
+	g.scene = NewScene(g, seed)
+	return g
+}
+
+// Draw clears the frame and draws the scene.
+func (g *Game) Draw(alpha float64) {
+	g.renderer.Clear()
+	g.scene.Draw(alpha)
+
+	if g.errText != "" {
+		g.stats.font.SetColor(1.0, 0.2, 0.2, 1.0)
+		for i, l := range strings.Split(g.errText, "\n") {
+			g.stats.font.Printf(10, 60+float32(i*20), 1.2, "%s", l)
+		}
+	}
+}
+
+// SaveProfile saves the profile, and shows an error if that fails.
+func (g *Game) SaveProfile() {
+	if err := g.profile.Save(); err != nil {
+		g.ShowError(fmt.Sprintf("Saving profile failed:\n%v", err))
+	}
+}
+
+// ShowError shows msg on screen until the next call. An empty msg clears
+// it.
+func (g *Game) ShowError(msg string) {
+	if msg != "" {
+		log.Print(msg)
+	}
+	g.errText = msg
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+
+	"goo/gfx"
+)
+
+type headlessOptions struct {
+	level     int
+	frames    int
+	fuel      float64
+	seed      int64
+	render    string
+	snapshot  string
+	golden    string
+	playback  *Replay
+	recording *Replay
+	profile   *Profile
+}
+
+// runHeadless simulates a level for a number of frames without a window.
+// If fuel is set, each rocket is fuelled up to that amount and launched
+// as soon as it is ready. With the software renderer the last frame can
+// be written to a PNG and compared with a golden image.
+func runHeadless(conf Config, levels []Level, opts headlessOptions) {
+	var renderer gfx.Renderer = &gfx.NullRenderer{}
+	var soft *gfx.SoftRenderer
+	switch opts.render {
+	case "":
+	case "software":
+		soft = &gfx.SoftRenderer{Camera: newCamera()}
+		renderer = soft
+	default:
+		log.Fatalf("Unknown renderer: %s", opts.render)
+	}
+	if err := renderer.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	game := NewGame(renderer, conf, levels, opts.profile, opts.seed)
+	scene := game.scene
+
+	if opts.recording != nil {
+		scene.input.Record(opts.recording)
+	}
+
+	scene.gameMap.StartLevel(opts.level)
+	if scene.gameMap.currentLevel != opts.level {
+		log.Fatalf("No such level: %d", opts.level)
+	}
+
+	// A replay is run to its end.
+	if opts.playback != nil {
+		scene.input.Play(opts.playback)
+		opts.frames = opts.playback.Steps
+	}
+
+	for i := 0; i < opts.frames; i++ {
+		rocket := scene.rocket
+		if opts.fuel > 0 && !rocket.hasReleased {
+			if rocket.boost < opts.fuel && rocket.boost < rocket.maxBoost {
+				scene.input.Queue(Action{Type: ActionBoost})
+			} else {
+				scene.input.Queue(Action{Type: ActionRelease})
+			}
+		}
+		scene.Update(stepMs)
+	}
+
+	fmt.Printf("Level: %d\n", scene.gameMap.currentLevel)
+	fmt.Printf("Seed: %d\n", scene.seed)
+	fmt.Printf("Frames: %d (%0.2fs)\n", opts.frames, float64(opts.frames)*stepMs/1000)
+	fmt.Printf("Landed: %v\n", scene.rocket.landed)
+	fmt.Printf("Attempts left: %d/%d\n", scene.gameMap.retries, scene.gameMap.totalRetries)
+	fmt.Printf("Score: %d\n", scene.gameMap.total)
+	fmt.Printf("Objects: %d\n", len(scene.objects))
+	fmt.Printf("Particles: %d\n", scene.Particles.Active())
+
+	if soft == nil {
+		if opts.snapshot != "" || opts.golden != "" {
+			log.Fatal("Snapshots require the software renderer")
+		}
+		return
+	}
+
+	game.Draw(1)
+
+	if opts.snapshot != "" {
+		if err := writePNG(opts.snapshot, soft.Frame); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if opts.golden != "" {
+		diff, err := compareGolden(soft.Frame, opts.golden)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if diff > 0 {
+			log.Fatalf("Frame differs from %s in %d pixels", opts.golden, diff)
+		}
+		fmt.Printf("Frame matches %s\n", opts.golden)
+	}
+}
+
+func writePNG(file string, img image.Image) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
+
+// compareGolden returns the number of pixels that differ between the
+// frame and the golden PNG. Like snapshots, it is a file on disk rather
+// than a game file.
+func compareGolden(frame *image.RGBA, file string) (int, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", file, err)
+	}
+	golden := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(golden, golden.Bounds(), img, img.Bounds().Min, draw.Src)
+	if golden.Bounds() != frame.Bounds() {
+		return 0, fmt.Errorf("golden image %s is %v, frame is %v", file, golden.Bounds(), frame.Bounds())
+	}
+
+	diff := 0
+	for i := 0; i < len(frame.Pix); i += 4 {
+		if frame.Pix[i] != golden.Pix[i] || frame.Pix[i+1] != golden.Pix[i+1] || frame.Pix[i+2] != golden.Pix[i+2] {
+			diff++
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+type ActionType uint8
+
+const (
+	ActionBoost ActionType = iota + 1
+	ActionRelease
+	ActionRespawn
+	ActionStartLevel
+	ActionExplode
+	ActionThrust
+	ActionSteerLeft
+	ActionSteerRight
+	ActionRestartLevel
+)
+
+// Action is anything the player does that changes the simulation.
+type Action struct {
+	Type  ActionType
+	Level int
+	X     int
+	Y     int
+}
+
+func (a Action) Apply(s *Scene) {
+	switch a.Type {
+	case ActionBoost:
+		s.rocket.Boost()
+	case ActionRelease:
+		s.rocket.Release()
+	case ActionRespawn:
+		s.gameMap.CreateRocket()
+	case ActionStartLevel:
+		s.gameMap.StartRun(a.Level)
+	case ActionExplode:
+		s.Explode(float64(a.X), float64(a.Y), 50)
+	case ActionThrust:
+		s.rocket.Thrust()
+	case ActionSteerLeft:
+		s.rocket.Steer(-1)
+	case ActionSteerRight:
+		s.rocket.Steer(1)
+	case ActionRestartLevel:
+		s.gameMap.RestartLevel()
+	}
+}
+
+// Input drives the simulation. Actions are queued as keys are pressed
+// and applied at the start of the next step. The actions of each step
+// can be recorded, or taken from a replay instead of the queue.
+type Input struct {
+	scene  *Scene
+	step   int
+	queued []Action
+	record *Replay
+	replay *Replay
+	next   int
+}
+
+func (in *Input) Queue(a Action) {
+	in.queued = append(in.queued, a)
+}
+
+// Record starts recording the actions of every step to r, and the
+// flight mode of the run.
+func (in *Input) Record(r *Replay) {
+	in.record = r
+}
+
+// Play takes the actions from r instead of the queue until the end of
+// the replay. The run is flown in the flight mode of the replay from
+// then on, whatever the config says.
+func (in *Input) Play(r *Replay) {
+	in.replay = r
+	in.next = 0
+	in.scene.flightMode = r.FlightMode
+}
+
+// Replaying returns true while actions are taken from a replay.
+func (in *Input) Replaying() bool {
+	return in.replay != nil
+}
+
+// Step applies the actions of the current step and moves on to the next.
+func (in *Input) Step() {
+	actions := in.queued
+	in.queued = nil
+
+	if in.replay != nil {
+		actions = nil
+		for in.next < len(in.replay.actions) && in.replay.actions[in.next].step == in.step {
+			actions = append(actions, in.replay.actions[in.next].action)
+			in.next++
+		}
+	}
+
+	for _, a := range actions {
+		if in.record != nil {
+			in.record.Add(in.step, a)
+		}
+		a.Apply(in.scene)
+	}
+
+	in.step++
+	if in.record != nil {
+		in.record.Steps = in.step
+		in.record.FlightMode = in.scene.flightMode
+	}
+
+	// Hand over to the player when the replay is done.
+	if in.replay != nil && in.step >= in.replay.Steps {
+		in.replay = nil
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"time"
+
+	"github.com/go-gl/glfw/v3.1/glfw"
+)
+
+type KeyHandler struct {
+	game        *Game
+	mouseX      int
+	mouseY      int
+	mouseDown   bool
+	mouseButton int
+	WireFrame   bool
+	kDown       bool
+	Debug       bool
+	lastTime    time.Time
+	loadFuel    bool
+	release     bool
+	thrust      bool
+	steerLeft   bool
+	steerRight  bool
+}
+
+func (k *KeyHandler) MousePos(w *glfw.Window, xpos, ypos float64) {
+	k.mouseX = int(xpos)
+	k.mouseY = screenHeight - int(ypos)
+	if k.mouseDown && k.mouseButton == 0 && k.game.scene.states.Get().Play {
+		k.game.scene.input.Queue(Action{Type: ActionExplode, X: k.mouseX, Y: k.mouseY})
+	}
+}
+
+func (k *KeyHandler) MouseDown(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	if action == 1 {
+		k.mouseDown = true
+	} else {
+		k.mouseDown = false
+	}
+	k.mouseButton = int(button)
+}
+
+func (k *KeyHandler) Process(w *glfw.Window) {
+	conf := k.game.conf
+	menu := k.game.menu
+	states := k.game.scene.states
+	input := k.game.scene.input
+
+	k.loadFuel = false
+	k.release = false
+	k.thrust = false
+	k.steerLeft = false
+	k.steerRight = false
+
+	if glfw.Press == w.GetKey(GLKeys[conf.KeyMenu]) {
+		if time.Since(k.lastTime).Milliseconds() > 200 {
+			k.lastTime = time.Now()
+			if menu.showAbout {
+				menu.About()
+			} else if menu.showSelectLevel {
+				menu.SelectLevel()
+			} else if menu.showPacks {
+				menu.Packs()
+			} else {
+				k.togglePause()
+			}
+		}
+	}
+
+	if glfw.Press == w.GetKey(GLKeys[conf.KeyPause]) {
+		if time.Since(k.lastTime).Milliseconds() > 200 {
+			k.lastTime = time.Now()
+			k.togglePause()
+		}
+	}
+
+	// If menu is up, only handle keys for the menu
+	if states.Get().Menu {
+		if glfw.Press == w.GetKey(GLKeys[conf.KeyMenuUp]) {
+			if time.Since(k.lastTime).Milliseconds() > 150 {
+				k.lastTime = time.Now()
+				menu.Up()
+			}
+		}
+
+		if glfw.Press == w.GetKey(GLKeys[conf.KeyMenuDown]) {
+			if time.Since(k.lastTime).Milliseconds() > 150 {
+				k.lastTime = time.Now()
+				menu.Down()
+			}
+		}
+
+		if glfw.Press == w.GetKey(GLKeys[conf.KeyMenuSelect]) {
+			if time.Since(k.lastTime).Milliseconds() > 150 {
+				k.lastTime = time.Now()
+				menu.Select()
+			}
+		}
+		return
+	}
+
+	// Gameplay keys only while the state allows playing
+	if states.Get().Play {
+		k.loadFuel = glfw.Press == w.GetKey(GLKeys[conf.KeyLoadFuel])
+		k.release = glfw.Press == w.GetKey(GLKeys[conf.KeyRelease])
+		if k.game.scene.flightMode {
+			k.thrust = glfw.Press == w.GetKey(GLKeys[conf.KeyThrust])
+			k.steerLeft = glfw.Press == w.GetKey(GLKeys[conf.KeySteerLeft])
+			k.steerRight = glfw.Press == w.GetKey(GLKeys[conf.KeySteerRight])
+		}
+
+		if glfw.Press == w.GetKey(GLKeys[conf.KeyRespawn]) {
+			if time.Since(k.lastTime).Milliseconds() > 200 {
+				k.lastTime = time.Now()
+				input.Queue(Action{Type: ActionRespawn})
+			}
+		}
+	}
+
+	if glfw.Press == w.GetKey(GLKeys[conf.KeyDebugInfo]) {
+		if time.Since(k.lastTime).Milliseconds() > 200 {
+			k.lastTime = time.Now()
+			k.Debug = !k.Debug
+		}
+	}
+
+	if glfw.Press == w.GetKey(GLKeys[conf.KeyWireframe]) {
+		if time.Since(k.lastTime).Milliseconds() > 200 {
+			k.lastTime = time.Now()
+			k.WireFrame = !k.WireFrame
+		}
+	}
+}
+
+func (k *KeyHandler) togglePause() {
+	states := k.game.scene.states
+	if states.Is(StatePaused) {
+		states.Back()
+	} else if !states.Is(StateMenu) {
+		states.Set(StatePaused)
+	}
+}
+
+// Step queues the actions of the keys held down. It is called once per
+// simulation step, so fuel loads at the same rate at any frame rate.
+func (k *KeyHandler) Step() {
+	input := k.game.scene.input
+
+	if k.loadFuel {
+		input.Queue(Action{Type: ActionBoost})
+	}
+
+	if k.release {
+		input.Queue(Action{Type: ActionRelease})
+	}
+
+	if k.thrust {
+		input.Queue(Action{Type: ActionThrust})
+	}
+	if k.steerLeft {
+		input.Queue(Action{Type: ActionSteerLeft})
+	}
+	if k.steerRight {
+		input.Queue(Action{Type: ActionSteerRight})
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goo/loader"
+)
+
+// Level describes a single level, loaded from a JSON file in the
+// levels directory. Asset fields refer to keys in the assets config.
+type Level struct {
+	Name                string   `json:"name"`
+	Background          string   `json:"background"`
+	Map                 string   `json:"map"`
+	Moon                string   `json:"moon"`
+	MoonSpeed           float64  `json:"moonSpeed"`
+	Alien               string   `json:"alien"`
+	Aliens              int      `json:"aliens"`
+	AlienBombSpeed      float64  `json:"alienBombSpeed"`
+	AlienBombType       ShotType `json:"alienBombType"`
+	AlienBombFreq       int      `json:"alienBombFreq"`
+	AlienBombMaxTime    float64  `json:"alienBombMaxTime"`
+	AlienWaitForRelease bool     `json:"alienWaitForRelease"`
+	Satellite           string   `json:"satellite"`
+	Satellites          int      `json:"satellites"`
+	Debris              int      `json:"debris"`
+	MaxWind             float64  `json:"maxWind"`
+	Retries             int      `json:"retries"`
+	RocketX             float64  `json:"rocketX"`
+	RocketY             float64  `json:"rocketY"`
+	RocketBoostMax      float64  `json:"rocketBoostMax"`
+	LandingMaxSpeed     float64  `json:"landingMaxSpeed"`
+	LandingMaxTilt      float64  `json:"landingMaxTilt"`
+	// Collisions are entries of the collision matrix for the level, on
+	// top of the ones in the config.
+	Collisions map[string]string `json:"collisions"`
+}
+
+// Landing limits of levels that don't set them.
+const (
+	defaultLandingMaxSpeed = 200
+	defaultLandingMaxTilt  = 20
+)
+
+// LoadLevels loads every *.json file in dir, ordered by file name.
+func LoadLevels(dir string, assets map[string]string) ([]Level, error) {
+	files, err := loader.Files.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read levels: %v", err)
+	}
+
+	levels := []Level{}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		l, err := LoadLevel(filepath.Join(dir, f.Name()), assets)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, l)
+	}
+
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no levels found in %s", dir)
+	}
+	return levels, nil
+}
+
+// LoadLevel loads and validates a single level file.
+func LoadLevel(file string, assets map[string]string) (Level, error) {
+	var l Level
+
+	lFile, err := loader.Files.Open(file)
+	if err != nil {
+		return l, err
+	}
+	defer lFile.Close()
+
+	jsonParser := json.NewDecoder(lFile)
+	jsonParser.DisallowUnknownFields()
+	if err := jsonParser.Decode(&l); err != nil {
+		return l, fmt.Errorf("%s: %v", file, err)
+	}
+
+	if err := l.Validate(assets); err != nil {
+		return l, fmt.Errorf("%s: %v", file, err)
+	}
+
+	if l.LandingMaxSpeed == 0 {
+		l.LandingMaxSpeed = defaultLandingMaxSpeed
+	}
+	if l.LandingMaxTilt == 0 {
+		l.LandingMaxTilt = defaultLandingMaxTilt
+	}
+	return l, nil
+}
+
+// Validate checks that the level only refers to known assets and
+// that all values are within sane ranges.
+func (l *Level) Validate(assets map[string]string) error {
+	errs := []string{}
+
+	refs := [][2]string{
+		{"background", l.Background},
+		{"map", l.Map},
+		{"moon", l.Moon},
+	}
+	if l.Aliens > 0 {
+		refs = append(refs, [2]string{"alien", l.Alien})
+	}
+	if l.Satellites > 0 {
+		refs = append(refs, [2]string{"satellite", l.Satellite})
+	}
+	for _, a := range refs {
+		if _, ok := assets[a[1]]; !ok {
+			errs = append(errs, fmt.Sprintf("%s: unknown asset %q", a[0], a[1]))
+		}
+	}
+
+	if l.Name == "" {
+		errs = append(errs, "name: must be set")
+	}
+	if l.Aliens < 0 || l.Satellites < 0 || l.Debris < 0 {
+		errs = append(errs, "aliens, satellites and debris must not be negative")
+	}
+	if l.AlienBombFreq < 0 || l.AlienBombFreq > 100 {
+		errs = append(errs, "alienBombFreq: must be between 0 and 100")
+	}
+	if l.MaxWind < 0 {
+		errs = append(errs, "maxWind: must not be negative")
+	}
+	if l.Retries < 1 {
+		errs = append(errs, "retries: must be at least 1")
+	}
+	if l.RocketBoostMax <= 0 {
+		errs = append(errs, "rocketBoostMax: must be positive")
+	}
+	if l.LandingMaxSpeed < 0 || l.LandingMaxTilt < 0 {
+		errs = append(errs, "landingMaxSpeed and landingMaxTilt must not be negative")
+	}
+	for _, err := range CheckCollisions(l.Collisions) {
+		errs = append(errs, fmt.Sprintf("collisions.%v", err))
+	}
+	if l.RocketX < 0 || l.RocketX > screenWidth || l.RocketY < 0 || l.RocketY > screenHeight {
+		errs = append(errs, "rocketX/rocketY: must be on screen")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
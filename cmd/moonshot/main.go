@@ -0,0 +1,321 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-gl/gl/all-core/gl"
+	"github.com/go-gl/glfw/v3.1/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+
+	"goo"
+	"goo/gfx"
+	"goo/loader"
+)
+
+const (
+	screenWidth  = 1280
+	screenHeight = 1024
+)
+
+const (
+	// stepMs is the length of a simulation step. The simulation always
+	// advances in steps of this size, regardless of the frame rate.
+	stepMs = 1000.0 / 60
+	// maxFrameMs caps the time a single slow frame can add, so a stall
+	// doesn't leave the simulation trying to catch up for ever.
+	maxFrameMs = 250.0
+)
+
+type ObjectType int
+
+const (
+	ObjectRocket ObjectType = iota + 1
+	ObjectMoon
+	ObjectSatellite
+	ObjectAlien
+	ObjectShot
+	ObjectBackground
+	ObjectWorld
+	ObjectDebris
+)
+
+// Obj is an interface for game objects
+type Obj interface {
+	Update(dt float64)
+	Hit(x, y int, objType ObjectType)
+	SavePosition()
+	Draw(alpha float64)
+	GetBorderPixels() []float64
+	GetX() float64
+	GetY() float64
+	GetMove() (float64, float64)
+	GetBounds() (float64, float64, float64, float64)
+	IsActive(x, y int) bool
+	GetObjType() ObjectType
+	IsRemoved() bool
+	Clear()
+	Remove()
+	ReloadSprite(files map[string]string) error
+}
+
+func init() {
+	runtime.LockOSThread()
+}
+
+// newCamera returns the camera the renderers draw the screen with.
+func newCamera() gfx.Camera {
+	return gfx.Camera{
+		Width:      screenWidth,
+		Height:     screenHeight,
+		View:       mgl32.Translate3D(-screenWidth/2, -screenHeight/2, -screenWidth+43),
+		Projection: mgl32.Perspective(mgl32.DegToRad(45.0), float32(screenWidth)/float32(screenHeight), 0.1, 2000.0),
+	}
+}
+
+func main() {
+	headless := flag.Bool("headless", false, "run the simulation without a window, OpenGL or sound")
+	level := flag.Int("level", 1, "level to start")
+	frames := flag.Int("frames", 600, "simulation steps (1/60 s) to run in headless mode")
+	fuel := flag.Float64("fuel", 0, "fuel to load before each launch in headless mode")
+	render := flag.String("render", "", "renderer to use in headless mode, \"software\" or none")
+	snapshot := flag.String("snapshot", "", "PNG file to write the last frame to in headless mode")
+	golden := flag.String("golden", "", "PNG file to compare the last frame with in headless mode")
+	seed := flag.Int64("seed", 0, "random seed, 0 picks one from the clock")
+	record := flag.String("record", "", "file to record the run to")
+	replay := flag.String("replay", "", "replay file to play back")
+	configFile := flag.String("config", "", "config file to use instead of "+UserConfigFile())
+	printConfig := flag.Bool("print-config", false, "print the merged config and exit")
+	profileFile := flag.String("profile", "", "save file to use instead of "+ProfileFile()+", headless mode only uses one if set")
+	dataDir := flag.String("data", "", "directory with files that replace the built-in ones, e.g. . to use ./assets, and that are reloaded when they change")
+	var sets setFlags
+	flag.Var(&sets, "set", "set a config value, e.g. -set assets.rocket=rocket.png (repeatable)")
+	flag.Parse()
+
+	loader.Files = &loader.FS{Dir: *dataDir, Builtin: goo.Assets}
+
+	if flag.Arg(0) == "config" {
+		os.Exit(runConfigCommand(flag.Args()[1:]))
+	}
+
+	userFile := *configFile
+	if userFile == "" {
+		userFile = UserConfigFile()
+	}
+	if *profileFile == "" && !*headless {
+		*profileFile = ProfileFile()
+	}
+	profile, err := LoadProfile(*profileFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Settings from the menu come before the command line ones, which win.
+	profileSets := profile.Settings.sets()
+	conf, packs, err := LoadConfiguration(userFile, *configFile != "", append(profileSets, sets...))
+	if err != nil && len(profileSets) > 0 {
+		log.Printf("%v, ignoring the settings in %s", err, *profileFile)
+		profileSets = nil
+		conf, packs, err = LoadConfiguration(userFile, *configFile != "", sets)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	loader.Files.Packs = packFS(packs)
+	if *printConfig {
+		if err := conf.Print(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano() % 1000000000
+	}
+
+	var playback *Replay
+	if *replay != "" {
+		r, err := LoadReplay(*replay)
+		if err != nil {
+			log.Fatal(err)
+		}
+		*seed = r.Seed
+		*level = r.Level
+		playback = r
+	}
+
+	var recording *Replay
+	if *record != "" {
+		recording = &Replay{Seed: *seed, Level: *level}
+	}
+
+	levels, err := LoadLevels(conf.Levels, conf.Assets)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if playback != nil && playback.Hash != 0 && playback.Hash != RunHash(conf, levels) {
+		log.Printf("%s was recorded with other levels, collisions or assets, it may not play the same", *replay)
+	}
+	if recording != nil {
+		recording.Hash = RunHash(conf, levels)
+	}
+
+	if *headless {
+		runHeadless(conf, levels, headlessOptions{
+			level:     *level,
+			frames:    *frames,
+			fuel:      *fuel,
+			seed:      *seed,
+			render:    *render,
+			snapshot:  *snapshot,
+			golden:    *golden,
+			playback:  playback,
+			recording: recording,
+			profile:   profile,
+		})
+		saveRecording(*record, recording)
+		return
+	}
+
+	if err := glfw.Init(); err != nil {
+		log.Fatal(err)
+	}
+	defer glfw.Terminate()
+
+	glfw.WindowHint(glfw.Resizable, glfw.False)
+	glfw.WindowHint(glfw.ContextVersionMajor, 3)
+	glfw.WindowHint(glfw.ContextVersionMinor, 3)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, gl.TRUE)
+
+	window, err := glfw.CreateWindow(screenWidth, screenHeight, "MoonShot", nil, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer window.Destroy()
+
+	window.MakeContextCurrent()
+	window.SetFramebufferSizeCallback(frameBufferSizeCallback)
+
+	if err := gl.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	width, height := window.GetFramebufferSize()
+	gl.Viewport(0, 0, int32(width), int32(height))
+
+	renderer := &gfx.GLRenderer{Camera: newCamera(), Shaders: conf.Shaders}
+	if err := renderer.Init(); err != nil {
+		panic(err)
+	}
+
+	// Initiate misc stuff
+	game := NewGame(renderer, conf, levels, profile, *seed)
+	scene := game.scene
+	game.sound.Init()
+
+	// Load sounds
+	for k, v := range conf.Sounds {
+		if err := game.sound.Load(v, k); err != nil {
+			panic(err)
+		}
+	}
+
+	game.sound.Play("bgmusic", 0.3)
+
+	if playback != nil {
+		scene.input.Play(playback)
+	}
+	if recording != nil {
+		scene.input.Record(recording)
+	}
+
+	// Key handler
+	keyHandler := &KeyHandler{game: game}
+	window.SetCursorPosCallback(keyHandler.MousePos)
+	window.SetMouseButtonCallback(keyHandler.MouseDown)
+
+	scene.gameMap.StartLevel(*level)
+
+	// Built-in files never change, only the ones on disk are watched.
+	if *dataDir == "" {
+		log.Print("Assets and shaders are built in and not reloaded, use -data to reload them while the game runs")
+	}
+	game.reloader = NewReloader(game, packs, userFile, *configFile != "", append(profileSets, sets...))
+
+	// render loop
+	acc := float64(0)
+	lastTS := time.Now()
+	for !window.ShouldClose() {
+		frameMs := float64(time.Since(lastTS).Microseconds()) / 1000
+		lastTS = time.Now()
+		if frameMs > maxFrameMs {
+			frameMs = maxFrameMs
+		}
+
+		game.reloader.Poll()
+		keyHandler.Process(window)
+
+		game.stats.Update()
+
+		acc = scene.states.Advance(acc, frameMs, func() {
+			keyHandler.Step()
+			scene.Update(stepMs)
+		})
+
+		// Draw in between the last two steps.
+		alpha := acc / stepMs
+
+		renderer.SetWireframe(keyHandler.WireFrame)
+		game.Draw(alpha)
+
+		if keyHandler.Debug {
+			renderer.SetWireframe(false)
+			game.stats.Draw()
+		}
+
+		if scene.states.Get().Menu {
+			game.menu.Draw(alpha)
+		}
+		if game.menu.shouldQuit {
+			window.SetShouldClose(true)
+		}
+
+		window.SwapBuffers()
+		glfw.PollEvents()
+	}
+
+	scene.World.Clear(renderer)
+	scene.gameMap.ClearCurrent()
+
+	saveRecording(*record, recording)
+}
+
+// setFlags collects the values of a repeated flag.
+type setFlags []string
+
+func (s *setFlags) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *setFlags) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func saveRecording(file string, r *Replay) {
+	if r == nil {
+		return
+	}
+	if err := SaveReplay(file, r); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func frameBufferSizeCallback(w *glfw.Window, width int, height int) {
+	gl.Viewport(0, 0, int32(width), int32(height))
+}
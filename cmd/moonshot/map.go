@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"goo/gfx"
+	"goo/loader"
+	"goo/voxel"
+)
+
+type Map struct {
+	scene        *Scene
+	levels       []Level
+	level        Level
+	currentLevel int
+	Wind         float64
+	retries      int
+	totalRetries int
+	startMs      float64
+	aliens       int
+	score        Score
+	total        int
+	startTotal   int
+	font         gfx.Font
+	text         string
+	textColor    Color
+}
+
+func (m *Map) Init(s *Scene) {
+	m.scene = s
+	m.levels = s.game.levels
+
+	font, err := s.game.renderer.LoadFont(s.game.conf.Assets["menuFont"], int32(72))
+	if err != nil {
+		panic(err)
+	}
+	m.font = font
+}
+
+func (m *Map) Draw(alpha float64) {
+	s := m.scene
+	rocket := s.rocket
+	conf := s.game.conf
+	stats := s.game.stats
+
+	if m.text != "" {
+		m.font.SetColor(m.textColor.R, m.textColor.G, m.textColor.B, m.textColor.A)
+		m.font.Printf(screenWidth/2-float32(len(m.text)*15), screenHeight/2, 1.0, m.text)
+	}
+
+	if s.states.Get().HUD {
+		mc := conf.Colors["fuel"]
+		rocket.boostFont.SetColor(mc.R, mc.G, mc.B, mc.A)
+		if s.flightMode && rocket.hasReleased {
+			rocket.boostFont.Printf(screenWidth/2-180, screenHeight-60, 1.0, fmt.Sprintf("Flight fuel: %0.2f %v", (rocket.flightFuel/rocket.maxBoost*100), "%%"))
+		} else {
+			rocket.boostFont.Printf(screenWidth/2-100, screenHeight-60, 1.0, fmt.Sprintf("Fuel: %0.2f %v", (rocket.boost/rocket.maxBoost*100), "%%"))
+		}
+
+		if m.Wind != 0 {
+			mc := conf.Colors["wind"]
+			rocket.boostFont.SetColor(mc.R, mc.G, mc.B, mc.A)
+			w := ""
+			if m.Wind < 0 {
+				w = fmt.Sprintf("Wind: <- %0.2f", math.Abs(m.Wind))
+			} else {
+				w = fmt.Sprintf("Wind: %0.2f ->", math.Abs(m.Wind))
+			}
+			rocket.boostFont.Printf(screenWidth/2-100, screenHeight-4, 1.0, w)
+		}
+
+		stats.font.SetColor(1.0, 1.0, 1.0, 0.7)
+		stats.font.Printf(10, screenHeight-20, 1.1, "<space> - Fuel")
+		stats.font.Printf(10, screenHeight-4, 1.1, "<enter> - Launch")
+		if s.flightMode {
+			stats.font.Printf(10, screenHeight-36, 1.1, "<arrows> - Burn and steer")
+		}
+		stats.font.Printf(screenWidth-150, screenHeight-4, 1.1, "Seed: %d", s.seed)
+		stats.font.Printf(screenWidth-150, screenHeight-36, 1.1, "Score: %d", m.total)
+		if s.input.Replaying() {
+			stats.font.Printf(screenWidth-150, screenHeight-20, 1.1, "Replay")
+		}
+	}
+
+	if s.states.Is(StateLevelComplete) {
+		m.drawSummary()
+	} else if s.states.Is(StateVictory) {
+		rocket.boostFont.SetColor(1.0, 1.0, 1.0, 1.0)
+		rocket.boostFont.Printf(screenWidth/2-200, screenHeight/2+80, 0.8, "Total score: %d", m.total)
+	}
+}
+
+// drawSummary shows the score of the level by part, and the total.
+func (m *Map) drawSummary() {
+	font := m.scene.rocket.boostFont
+	lines := []struct {
+		name   string
+		points int
+	}{
+		{"Fuel", m.score.Fuel},
+		{"Attempts", m.score.Attempts},
+		{"Time", m.score.Time},
+		{"Aliens", m.score.Aliens},
+		{"Landing", m.score.Landing},
+		{"Zone", m.score.Zone},
+		{"Level score", m.score.Total()},
+		{"Total score", m.total},
+	}
+	for i, l := range lines {
+		font.SetColor(1.0, 1.0, 1.0, 0.9)
+		if i >= 6 {
+			font.SetColor(0.0, 1.0, 0.0, 1.0)
+		}
+		y := screenHeight/2 + 80 + float32(i*36)
+		font.Printf(screenWidth/2-200, y, 0.7, "%s", l.name)
+		font.Printf(screenWidth/2+100, y, 0.7, "%d", l.points)
+	}
+}
+
+// StartRun starts a run of levels from level, with the total score at 0.
+func (m *Map) StartRun(level int) {
+	m.total = 0
+	m.StartLevel(level)
+}
+
+// RestartLevel starts the current level again. The total goes back to what
+// it was when the level started, so only the score of this level is taken
+// back.
+func (m *Map) RestartLevel() {
+	m.total = m.startTotal
+	m.StartLevel(m.currentLevel)
+}
+
+func (m *Map) StartLevel(level int) {
+	s := m.scene
+	if level < 1 || level > len(m.levels) {
+		return
+	}
+
+	// Timers and sounds from the previous level must not go on in this one.
+	s.scheduler.CancelAll()
+	s.game.sound.StopAll()
+
+	m.textColor = Color{R: 1.0, G: 1.0, B: 0.0, A: 1.0}
+	m.text = fmt.Sprintf("Level %d", level)
+	s.scheduler.After(2000, func() {
+		m.text = ""
+	})
+
+	m.currentLevel = level
+	m.level = m.levels[level-1]
+	m.startMs = s.scheduler.now
+	m.aliens = 0
+	m.score = Score{}
+	m.startTotal = m.total
+
+	s.SeedLevel(level)
+
+	m.Wind = 0
+	if m.level.MaxWind > 0 {
+		m.Wind = m.level.MaxWind - s.Rand.Float64()*m.level.MaxWind*2
+	}
+
+	m.LoadLevel(m.level)
+	s.states.Set(StatePlaying)
+}
+
+func (m *Map) LoadLevel(l Level) {
+	s := m.scene
+	assets := s.game.conf.Assets
+
+	m.ClearCurrent()
+
+	m.retries = l.Retries
+	m.totalRetries = l.Retries
+
+	collisions, err := NewCollisions(s.game.conf.Collisions, l.Collisions)
+	if err != nil {
+		panic(err)
+	}
+	s.collisions = collisions
+
+	s.World.Init(screenWidth, screenHeight)
+
+	// Set background
+	if err := s.background.Init(assets[l.Background]); err != nil {
+		panic(err)
+	}
+
+	// Load the foreground/map
+	if err := loader.LoadMap(s.World, assets[l.Map]); err != nil {
+		panic(err)
+	}
+
+	// Set moon
+	s.moon.Init(s, 600, 800, 1, assets[l.Moon], ObjectMoon)
+	s.moon.speed = l.MoonSpeed
+	s.AddObject(s.moon)
+
+	// Create satellites
+	for i := 0; i < l.Satellites; i++ {
+		sat := &Satellite{origX: 500 + s.Rand.Float64()*100, origY: -300 * s.Rand.Float64()}
+		sat.Rotation = s.Rand.Float64() * 100
+		sat.Init(s, 0, 0, 2, assets[l.Satellite], ObjectSatellite)
+		s.AddObject(sat)
+	}
+
+	// Debris around moon
+	for i := 0; i < l.Debris; i++ {
+		d := &Debris{
+			origX: 300 - s.Rand.Float64()*600,
+			origY: 300 - s.Rand.Float64()*600,
+		}
+		dType := fmt.Sprintf("rock%d", s.Rand.Intn(7)+1)
+		d.Init(s, s.Rand.Float64()*screenWidth, screenHeight-screenHeight/4, 2, assets[dType], ObjectDebris)
+		s.AddObject(d)
+	}
+
+	// Create alien ships
+	for i := 0; i < l.Aliens; i++ {
+		a := &Alien{
+			AmmoSpeed:      l.AlienBombSpeed,
+			AmmoFreq:       l.AlienBombFreq,
+			AmmoType:       l.AlienBombType,
+			AmmoMaxTime:    l.AlienBombMaxTime,
+			WaitForRelease: l.AlienWaitForRelease,
+		}
+		a.Init(s, s.Rand.Float64()*screenWidth, screenHeight-screenHeight/4, 2, assets[l.Alien], ObjectAlien)
+		s.AddObject(a)
+	}
+	// Create rocket
+	m.CreateRocket()
+}
+
+func (m *Map) ClearCurrent() {
+	s := m.scene
+	s.background.Clear(s.game.renderer)
+	for i := range s.objects {
+		s.objects[i].Remove()
+	}
+	s.RemoveObjects()
+
+	s.objects = []Obj{}
+	s.moon = &Moon{}
+	s.rocket = &Rocket{}
+	s.background = &gfx.Background{}
+	*s.World = voxel.World{}
+}
+
+func (m *Map) Reset() {
+	s := m.scene
+	if !s.input.Replaying() {
+		s.game.profile.Failed(m.currentLevel)
+		s.game.SaveProfile()
+	}
+
+	m.retries--
+	if m.retries == 0 {
+		s.states.Set(StateFailed)
+	} else {
+		// Failed attemp, retry!
+		m.textColor = Color{R: 1.0, G: 1.0, B: 0.0, A: 1.0}
+		m.text = fmt.Sprintf("Attempt: %d/%d", m.retries, m.totalRetries)
+		s.scheduler.After(3000, func() {
+			m.text = ""
+		})
+		m.CreateRocket()
+	}
+}
+
+func (m *Map) Landed() {
+	s := m.scene
+	l := Landing{
+		Fuel:       s.rocket.FuelUsed() / s.rocket.maxBoost,
+		Attempt:    m.totalRetries - m.retries + 1,
+		Attempts:   m.totalRetries,
+		TimeMs:     s.scheduler.now - m.startMs,
+		Aliens:     m.aliens,
+		Speed:      s.rocket.landSpeed,
+		TiltDeg:    s.rocket.landTilt,
+		MaxSpeed:   m.level.LandingMaxSpeed,
+		MaxTiltDeg: m.level.LandingMaxTilt,
+		Zone:       s.rocket.landZone,
+	}
+	m.score = l.Score()
+	m.total += m.score.Total()
+
+	if !s.input.Replaying() {
+		s.game.profile.Landed(m.currentLevel, l, m.score.Total(), m.total)
+		s.game.SaveProfile()
+	}
+	s.states.Set(StateLevelComplete)
+}
+
+// Completed is run when the level is complete, and moves on to the next
+// level or to the victory.
+func (m *Map) Completed() {
+	m.textColor = Color{R: 0.0, G: 1.0, B: 0.0, A: 1.0}
+	m.text = "MoonShot project: SUCCESS!"
+	m.scene.game.sound.Play("success", 1.0)
+	m.scene.scheduler.After(3000, func() {
+		if m.currentLevel == len(m.levels) {
+			m.scene.states.Set(StateVictory)
+		} else {
+			next := m.currentLevel + 1
+			m.scene.scheduler.After(3000, func() {
+				m.text = ""
+				m.StartLevel(next)
+			})
+		}
+	})
+}
+
+// Failed is run when all attempts are used, and goes back to the menu.
+func (m *Map) Failed() {
+	m.textColor = Color{R: 1.0, G: 0.0, B: 0.0, A: 1.0}
+	m.text = "MoonShot Project: FAILED."
+	m.scene.scheduler.After(3000, func() {
+		m.text = ""
+		m.scene.states.Set(StateMenu)
+	})
+}
+
+// Victory is run when all levels are done.
+func (m *Map) Victory() {
+	m.textColor = Color{R: 0.0, G: 1.0, B: 0.0, A: 1.0}
+	m.text = "All moons visited, congratz!"
+	s := m.scene
+	s.scheduler.Every(100, func() {
+		s.Explode(s.Rand.Float64()*screenWidth, s.Rand.Float64()*screenHeight, s.Rand.Float64()*50)
+	})
+}
+
+func (m *Map) CreateRocket() {
+	s := m.scene
+	s.rocket.removed = true
+	r := Rocket{maxBoost: m.level.RocketBoostMax, initSleepMs: 2000}
+	r.Init(s, m.level.RocketX, m.level.RocketY, 10, s.game.conf.Assets["rocket"], ObjectRocket)
+	s.AddObject(&r)
+	s.rocket = &r
+}
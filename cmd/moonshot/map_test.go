@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestRestartLevel(t *testing.T) {
+	s := newTestGame(t, false).scene
+	m := s.gameMap
+
+	m.StartRun(1)
+	m.total = 500
+	m.StartLevel(2)
+	// Landing on level 2 adds its score.
+	m.total += 300
+
+	Action{Type: ActionRestartLevel}.Apply(s)
+	if m.currentLevel != 2 || m.total != 500 {
+		t.Errorf("after restart: level %d, total %d, want level 2, total 500", m.currentLevel, m.total)
+	}
+
+	Action{Type: ActionStartLevel, Level: 1}.Apply(s)
+	if m.currentLevel != 1 || m.total != 0 {
+		t.Errorf("after a new run: level %d, total %d, want level 1, total 0", m.currentLevel, m.total)
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"fmt"
+
+	"goo/gfx"
+)
+
+type Menu struct {
+	game               *Game
+	font               gfx.Font
+	aboutFont          gfx.Font
+	currentItem        int
+	menuItems          []string
+	levels             []string
+	menuCalls          []func()
+	pauseItems         []string
+	pauseCalls         []func()
+	shouldQuit         bool
+	showAbout          bool
+	showSelectLevel    bool
+	currentLevelSelect int
+	showPacks          bool
+	packs              []string
+	currentPack        int
+	confirmReset       bool
+}
+
+func (m *Menu) Init(g *Game) {
+	m.game = g
+
+	font, err := g.renderer.LoadFont(g.conf.Assets["menuFont"], int32(72))
+	if err != nil {
+		panic(err)
+	}
+	m.font = font
+
+	font, err = g.renderer.LoadFont(g.conf.Assets["statsFont"], int32(22))
+	if err != nil {
+		panic(err)
+	}
+	m.aboutFont = font
+
+	m.currentItem = 0
+	m.currentLevelSelect = 0
+
+	m.menuItems = []string{
+		"START",
+		"SELECT LEVEL",
+		"PACKS",
+		"RESET PROGRESS",
+		"ABOUT",
+		"QUIT",
+	}
+	m.menuCalls = []func(){
+		m.Start,
+		m.SelectLevel,
+		m.Packs,
+		m.ResetProgress,
+		m.About,
+		m.Quit,
+	}
+
+	m.pauseItems = []string{
+		"RESUME",
+		"RESTART LEVEL",
+		"MAIN MENU",
+	}
+	m.pauseCalls = []func(){
+		m.Resume,
+		m.Restart,
+		m.MainMenu,
+	}
+
+	m.levels = []string{}
+	for i, l := range g.levels {
+		m.levels = append(m.levels, fmt.Sprintf("Level %d - %s", i+1, l.Name))
+	}
+}
+
+func (m *Menu) Start() {
+	m.game.scene.states.Set(StatePlaying)
+	m.game.scene.input.Queue(Action{Type: ActionStartLevel, Level: 1})
+}
+
+func (m *Menu) SelectLevel() {
+	m.showSelectLevel = !m.showSelectLevel
+}
+
+// Packs shows the resource packs in PackDir, or hides them again.
+func (m *Menu) Packs() {
+	m.showPacks = !m.showPacks
+	m.packs = ListPacks()
+	m.currentPack = 0
+}
+
+// TogglePack turns the selected pack on, on top of the others, or off.
+func (m *Menu) TogglePack() {
+	if len(m.packs) == 0 {
+		return
+	}
+	name := m.packs[m.currentPack]
+
+	packs := []string{}
+	for _, p := range m.game.conf.Packs {
+		if p != name {
+			packs = append(packs, p)
+		}
+	}
+	if len(packs) == len(m.game.conf.Packs) {
+		packs = append(packs, name)
+	}
+	m.game.reloader.SetPacks(packs)
+	m.game.profile.Settings.Packs = m.game.conf.Packs
+	m.game.SaveProfile()
+}
+
+// ResetProgress resets the progress in the profile when selected a second
+// time, to confirm.
+func (m *Menu) ResetProgress() {
+	if !m.confirmReset {
+		m.confirmReset = true
+		return
+	}
+	m.confirmReset = false
+	m.currentLevelSelect = 0
+	m.game.profile.ResetProgress()
+	m.game.SaveProfile()
+}
+
+func (m *Menu) About() {
+	m.showAbout = !m.showAbout
+}
+
+func (m *Menu) Quit() {
+	m.shouldQuit = true
+}
+
+func (m *Menu) Resume() {
+	m.game.scene.states.Back()
+}
+
+func (m *Menu) Restart() {
+	m.game.scene.states.Set(StatePlaying)
+	m.game.scene.input.Queue(Action{Type: ActionRestartLevel})
+}
+
+func (m *Menu) MainMenu() {
+	m.game.scene.states.Set(StateMenu)
+}
+
+// items returns the items of the pause menu when paused, otherwise the
+// items of the main menu.
+func (m *Menu) items() ([]string, []func()) {
+	if m.game.scene.states.Is(StatePaused) {
+		return m.pauseItems, m.pauseCalls
+	}
+	return m.menuItems, m.menuCalls
+}
+
+func (m *Menu) Select() {
+	if m.showSelectLevel {
+		if m.currentLevelSelect+1 > m.game.profile.Unlocked {
+			return
+		}
+		m.game.scene.states.Set(StatePlaying)
+		m.game.scene.input.Queue(Action{Type: ActionStartLevel, Level: m.currentLevelSelect + 1})
+	} else if m.showPacks {
+		m.TogglePack()
+	} else {
+		_, calls := m.items()
+		calls[m.currentItem]()
+	}
+}
+
+func (m *Menu) Up() {
+	m.confirmReset = false
+	if m.showSelectLevel {
+		if m.currentLevelSelect != 0 {
+			m.currentLevelSelect--
+		} else {
+			m.currentLevelSelect = len(m.levels) - 1
+		}
+	} else if m.showPacks {
+		if m.currentPack != 0 {
+			m.currentPack--
+		} else {
+			m.currentPack = len(m.packs) - 1
+		}
+	} else {
+		items, _ := m.items()
+		if m.currentItem != 0 {
+			m.currentItem--
+		} else {
+			m.currentItem = len(items) - 1
+		}
+	}
+}
+
+func (m *Menu) Down() {
+	m.confirmReset = false
+	if m.showSelectLevel {
+		if m.currentLevelSelect != len(m.levels)-1 {
+			m.currentLevelSelect++
+		} else {
+			m.currentLevelSelect = 0
+		}
+	} else if m.showPacks {
+		if m.currentPack < len(m.packs)-1 {
+			m.currentPack++
+		} else {
+			m.currentPack = 0
+		}
+	} else {
+		items, _ := m.items()
+		if m.currentItem != len(items)-1 {
+			m.currentItem++
+		} else {
+			m.currentItem = 0
+		}
+	}
+}
+
+func (m *Menu) Draw(alpha float64) {
+	conf := m.game.conf
+
+	// Clear background text if menu is shown.
+	m.game.scene.gameMap.text = ""
+
+	if m.showAbout {
+		lines := []string{
+			"     This game was made by Magnus Persson",
+			"",
+			"           For the #GitHubGameOff 2020",
+			"",
+			"Source code: github.com/lallassu/moonshot",
+			"Twitter: @lallassu",
+			"",
+			"Credits:",
+			"Planets/Ships: https://opengameart.org/content/space-game-art-pack-extended",
+			"Map Gfx: ansimuz.com",
+			"Sound: soundbible.com",
+			"Music: https://dos88.itch.io/dos-88-music-library",
+		}
+		for i, s := range lines {
+			if i < 6 {
+				mc := conf.Colors["aboutFirst"]
+				m.aboutFont.SetColor(mc.R, mc.G, mc.B, mc.A)
+			} else {
+				mc := conf.Colors["aboutCredits"]
+				m.aboutFont.SetColor(mc.R, mc.G, mc.B, mc.A)
+			}
+			m.aboutFont.Printf(screenWidth/3, screenHeight/3+float32(i*22), 1.0, s)
+		}
+	} else if m.showSelectLevel {
+		profile := m.game.profile
+		for i, s := range m.levels {
+			m.font.SetColor(float32(i)*0.2, 1.0-float32(i)*0.1, 0, 1.0)
+			m.aboutFont.SetColor(1.0, 1.0, 1.0, 0.7)
+			if i+1 > profile.Unlocked {
+				m.font.SetColor(0.4, 0.4, 0.4, 1.0)
+				m.aboutFont.Printf(screenWidth-300, screenHeight/5+float32(i*80), 1.0, "Locked")
+			} else if r := profile.Result(i + 1); r.Landings > 0 {
+				m.aboutFont.Printf(screenWidth-300, screenHeight/5+float32(i*80), 1.0, "Best: %d points", r.BestScore)
+				m.aboutFont.Printf(screenWidth-300, screenHeight/5+float32(i*80)+22, 1.0, "%d attempts, %.1f s", r.BestAttempts, r.BestTimeMs/1000)
+			}
+			if i == m.currentLevelSelect {
+				m.font.Printf(screenWidth/5, screenHeight/5+float32(i*80), 1.3, s)
+			} else {
+				m.font.Printf(screenWidth/5, screenHeight/5+float32(i*80), 1.0, s)
+			}
+		}
+	} else if m.showPacks {
+		m.drawPacks()
+	} else {
+		items, _ := m.items()
+		for i, k := range items {
+			if i == m.currentItem && m.confirmReset {
+				k = "REALLY RESET?"
+			}
+			if i == m.currentItem {
+				mc := conf.Colors["menuRegular"]
+				m.font.SetColor(mc.R, mc.G, mc.B, mc.A)
+				m.font.Printf(screenWidth/3, screenHeight/3+float32(i*90), 1.5, k)
+			} else {
+				mc := conf.Colors["menuSelected"]
+				m.font.SetColor(mc.R, mc.G, mc.B, mc.A)
+				m.font.Printf(screenWidth/3, screenHeight/3+float32(i*80), 1.0, k)
+			}
+		}
+	}
+}
+
+// drawPacks lists the packs, with the priority of the ones turned on.
+func (m *Menu) drawPacks() {
+	conf := m.game.conf
+
+	if len(m.packs) == 0 {
+		mc := conf.Colors["aboutFirst"]
+		m.aboutFont.SetColor(mc.R, mc.G, mc.B, mc.A)
+		m.aboutFont.Printf(screenWidth/5, screenHeight/3, 1.0, "No resource packs found in %s", PackDir())
+		return
+	}
+
+	for i, name := range m.packs {
+		s := name + " - OFF"
+		for prio, p := range conf.Packs {
+			if p == name {
+				s = fmt.Sprintf("%s - ON %d", name, prio+1)
+			}
+		}
+		if i == m.currentPack {
+			mc := conf.Colors["menuRegular"]
+			m.font.SetColor(mc.R, mc.G, mc.B, mc.A)
+			m.font.Printf(screenWidth/5, screenHeight/5+float32(i*80), 1.3, s)
+		} else {
+			mc := conf.Colors["menuSelected"]
+			m.font.SetColor(mc.R, mc.G, mc.B, mc.A)
+			m.font.Printf(screenWidth/5, screenHeight/5+float32(i*80), 1.0, s)
+		}
+	}
+}
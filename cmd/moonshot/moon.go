@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math"
+)
+
+type Moon struct {
+	Object
+	released bool
+	boost    float64
+	speed    float64
+	zones    LandingZones
+}
+
+func (m *Moon) Init(s *Scene, x, y, z float64, img string, objType ObjectType) {
+	m.Object.Init(s, x, y, z, img, objType)
+
+	zones, err := LoadLandingZones(img)
+	if err != nil {
+		panic(err)
+	}
+	m.zones = zones
+	m.zones.Mark(&m.chunk)
+}
+
+// ReloadSprite reloads the sprite and its landing zones.
+func (m *Moon) ReloadSprite(files map[string]string) error {
+	if _, ok := files[m.img]; !ok {
+		return nil
+	}
+	if err := m.Object.ReloadSprite(files); err != nil {
+		return err
+	}
+
+	zones, err := LoadLandingZones(m.img)
+	if err != nil {
+		return err
+	}
+	m.zones = zones
+	m.zones.Mark(&m.chunk)
+	return nil
+}
+
+func (m *Moon) Update(dt float64) {
+	m.Active = false
+	m.Rotation += dt * m.speed / 1000
+	m.X = 600 + 300*math.Cos(m.Rotation)
+	m.Y = 800 + 100*math.Sin(m.Rotation)
+	m.Object.Update(dt)
+}
+
+func (m *Moon) Hit(x, y int, objType ObjectType) {
+	if objType == ObjectRocket && m.scene.rocket.landed {
+		return
+	}
+	switch m.collision(objType) {
+	case CollideExplode:
+		m.removed = true
+		m.Explode(int(m.X), int(m.Y))
+	case CollideDamage:
+		m.Explode(x, y)
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"goo/loader"
+	"goo/physics"
+	"goo/voxel"
+)
+
+type CustomFunc func(*Object, float64)
+type Object struct {
+	physics.Phys
+	scene    *Scene
+	chunk    voxel.Chunk
+	removed  bool
+	objType  ObjectType
+	boost    float64
+	elapsed  float64
+	released bool
+	lastX    float64
+	lastY    float64
+	moveX    float64
+	moveY    float64
+	steps    int
+	img      string
+}
+
+func (r *Object) Init(s *Scene, x, y, z float64, img string, objType ObjectType) {
+	r.scene = s
+	r.Space = &s.Space
+	r.Active = true
+	r.objType = objType
+
+	chunk, err := loader.LoadObject(img, z)
+	if err != nil {
+		panic(err)
+	}
+	r.chunk = chunk
+	r.chunk.X = x
+	r.chunk.Y = y
+	r.img = img
+	r.X = x
+	r.Y = y
+	r.lastX = x
+	r.lastY = y
+	r.Mass = 2
+	r.KeepAlive = true
+	r.Restitution = 0.1
+	r.Friction = 2
+	r.Active = true
+
+	r.BorderPixels = r.chunk.GetBorderPixels()
+}
+
+func (r *Object) Clear() {
+	r.chunk.Clear(r.scene.game.renderer)
+}
+
+func (r *Object) GetBorderPixels() []float64 {
+	return r.BorderPixels
+}
+
+func (r *Object) GetX() float64 {
+	return r.X
+}
+
+// GetMove returns how far the last step moved the object.
+func (r *Object) GetMove() (float64, float64) {
+	return r.moveX, r.moveY
+}
+
+// GetBounds returns the box around the blocks of the object over the last
+// step, from the bottom left to the top right.
+func (r *Object) GetBounds() (float64, float64, float64, float64) {
+	// Collisions are checked against whole blocks, round out.
+	x0, y0 := r.X-1, r.Y-1
+	x1, y1 := r.X+float64(r.chunk.SizeX)+1, r.Y+float64(r.chunk.SizeY)+1
+	return math.Min(x0, x0-r.moveX), math.Min(y0, y0-r.moveY), math.Max(x1, x1-r.moveX), math.Max(y1, y1-r.moveY)
+}
+
+func (r *Object) GetObjType() ObjectType {
+	return r.objType
+}
+
+func (r *Object) GetY() float64 {
+	return r.Y
+}
+
+func (r *Object) IsActive(x, y int) bool {
+	return r.chunk.IsActive(x, y, true)
+}
+
+func (r *Object) Update(dt float64) {
+	r.elapsed += dt
+	r.steps++
+	r.Phys.Update(dt)
+
+	// The chunk position is used for collisions, keep it in sync.
+	r.chunk.X = r.X
+	r.chunk.Y = r.Y
+}
+
+func (r *Object) Remove() {
+	r.removed = true
+}
+
+// SavePosition stores the position before a simulation step, so the
+// object can be drawn in between steps, and how far the last step moved it.
+// The first step places the object rather than moving it.
+func (r *Object) SavePosition() {
+	r.moveX, r.moveY = 0, 0
+	if r.steps > 1 {
+		r.moveX = r.X - r.lastX
+		r.moveY = r.Y - r.lastY
+	}
+	r.lastX = r.X
+	r.lastY = r.Y
+}
+
+// Draw draws the object alpha (0-1) of the way from the position
+// before the last step to the current position.
+func (r *Object) Draw(alpha float64) {
+	if r.removed {
+		return
+	}
+
+	// Don't draw off-screen except rocket
+	if r.objType != ObjectRocket {
+		if r.X > screenWidth+30 || r.X < -30 || r.Y > screenHeight+30 || r.Y < -30 {
+			return
+		}
+	}
+
+	r.chunk.X = r.lastX + (r.X-r.lastX)*alpha
+	r.chunk.Y = r.lastY + (r.Y-r.lastY)*alpha
+	r.chunk.Draw(r.scene.game.renderer)
+
+	// Restore, the chunk position is used for collisions.
+	r.chunk.X = r.X
+	r.chunk.Y = r.Y
+}
+
+// ReloadSprite rebuilds the sprite in place if the file it was loaded from
+// is in files, which maps it to the file to load instead. If loading fails
+// the current sprite is kept until the file is fixed.
+func (r *Object) ReloadSprite(files map[string]string) error {
+	file, ok := files[r.img]
+	if !ok {
+		return nil
+	}
+	r.img = file
+
+	chunk, err := loader.LoadObject(file, r.chunk.Z)
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	chunk.X = r.chunk.X
+	chunk.Y = r.chunk.Y
+	chunk.RotationDeg = r.chunk.RotationDeg
+	chunk.Scale = r.chunk.Scale
+
+	r.chunk.Clear(r.scene.game.renderer)
+	r.chunk = chunk
+	r.BorderPixels = r.chunk.GetBorderPixels()
+	return nil
+}
+
+func (r *Object) IsRemoved() bool {
+	return r.removed
+}
+
+func (r *Object) Hit(x, y int, objType ObjectType) {
+}
+
+// collision returns what happens to the object when it hits an object of
+// type objType, from the collision matrix.
+func (r *Object) collision(objType ObjectType) Collision {
+	return r.scene.collisions.Response(r.objType, objType)
+}
+
+func (r *Object) Explode(x, y int) {
+	power := 10 // TBD
+	pow := power * power
+	for rx := x - power; rx <= x+power; rx++ {
+		vx := (rx - x) * (rx - x)
+		for ry := y - power; ry <= y+power; ry++ {
+			if ry < 0 {
+				continue
+			}
+			val := (ry-y)*(ry-y) + vx
+			if val < pow {
+				b := r.chunk.GetBlock(rx, ry, true)
+				if !b.Used {
+					continue
+				}
+				r.chunk.Remove(rx, ry, true)
+
+				life := 5 + r.scene.Rand.Float64()*3
+				for i := 0; i < 5; i++ {
+					vx, vy := r.scene.burst(300)
+					r.scene.Particles.NewParticle(physics.Particle{
+						R:    b.R,
+						G:    b.G,
+						B:    b.B,
+						A:    b.A,
+						Size: float64(1 + r.scene.Rand.Intn(2)),
+						Phys: physics.Phys{
+							X:           float64(rx),
+							Y:           float64(ry) - r.scene.Rand.Float64()*10,
+							VX:          vx,
+							VY:          vy,
+							Life:        life,
+							Restitution: 0.3,
+							Friction:    1,
+							Active:      true,
+						},
+					})
+				}
+			}
+		}
+	}
+
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// packConfigFile is the optional config in the root of a resource pack.
+const packConfigFile = "config.json"
+
+// Pack is an open resource pack: a zip file with images, sounds and shaders
+// at the paths of the files they replace, e.g. assets/imgs/moon.png, and an
+// optional config.json merged on top of the built-in config.
+type Pack struct {
+	name string
+	zip  *zip.ReadCloser
+}
+
+// PackDir returns the directory resource packs are looked up in,
+// $XDG_CONFIG_HOME/moonshot/packs on Linux.
+func PackDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "moonshot", "packs")
+}
+
+// PackFile returns the file of the pack named name, which is either a file
+// name in PackDir or a path.
+func PackFile(name string) string {
+	if filepath.IsAbs(name) || strings.ContainsRune(name, filepath.Separator) {
+		return name
+	}
+	return filepath.Join(PackDir(), name)
+}
+
+// ListPacks returns the names of the packs in PackDir.
+func ListPacks() []string {
+	files, err := ioutil.ReadDir(PackDir())
+	if err != nil {
+		return nil
+	}
+
+	names := []string{}
+	for _, f := range files {
+		if !f.IsDir() && strings.EqualFold(filepath.Ext(f.Name()), ".zip") {
+			names = append(names, f.Name())
+		}
+	}
+	return names
+}
+
+// OpenPacks opens the named packs, in the same order.
+func OpenPacks(names []string) ([]*Pack, error) {
+	packs := []*Pack{}
+	for _, name := range names {
+		z, err := zip.OpenReader(PackFile(name))
+		if err != nil {
+			ClosePacks(packs)
+			return nil, fmt.Errorf("pack %s: %v", name, err)
+		}
+		packs = append(packs, &Pack{name: name, zip: z})
+	}
+	return packs, nil
+}
+
+func ClosePacks(packs []*Pack) {
+	for _, p := range packs {
+		p.zip.Close()
+	}
+}
+
+// packFS returns the packs as file systems for loader.FS.
+func packFS(packs []*Pack) []fs.FS {
+	list := []fs.FS{}
+	for _, p := range packs {
+		list = append(list, p)
+	}
+	return list
+}
+
+func (p *Pack) Open(name string) (fs.File, error) {
+	return p.zip.Open(name)
+}
+
+// decodeConfig merges the config of the pack, if it has one, into conf.
+func (p *Pack) decodeConfig(conf *Config) error {
+	f, err := p.zip.Open(packConfigFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("pack %s: %v", p.name, err)
+	}
+	defer f.Close()
+
+	if err := conf.decode(f); err != nil {
+		return fmt.Errorf("pack %s: %s: %v", p.name, packConfigFile, err)
+	}
+	return nil
+}
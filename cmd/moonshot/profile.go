@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Profile is the progress and settings of the player, saved between runs.
+type Profile struct {
+	// Unlocked is the highest level that can be started from the menu.
+	Unlocked int `json:"unlocked"`
+	// Attempts is the number of rockets launched on any level.
+	Attempts int `json:"attempts"`
+	// BestTotal is the highest total score of a run of levels.
+	BestTotal int `json:"bestTotal"`
+	// Levels are the results by level number.
+	Levels   map[string]LevelResult `json:"levels"`
+	Settings Settings               `json:"settings"`
+
+	file string
+}
+
+// LevelResult is the record of a level. The best attempts, time and score
+// are from the landings, 0 until the level has been landed.
+type LevelResult struct {
+	Attempts     int     `json:"attempts"`
+	Landings     int     `json:"landings"`
+	BestAttempts int     `json:"bestAttempts"`
+	BestTimeMs   float64 `json:"bestTimeMs"`
+	BestScore    int     `json:"bestScore"`
+}
+
+// Settings are the choices made in the menu, which override the config.
+type Settings struct {
+	// Packs are the resource packs turned on, or nil to use the config.
+	Packs []string `json:"packs"`
+}
+
+// ProfileFile returns the path of the save file,
+// $XDG_DATA_HOME/moonshot/profile.json on Linux, where XDG_DATA_HOME
+// defaults to ~/.local/share, and in the user config directory elsewhere.
+func ProfileFile() string {
+	dir := ""
+	if runtime.GOOS == "linux" {
+		dir = os.Getenv("XDG_DATA_HOME")
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return ""
+			}
+			dir = filepath.Join(home, ".local", "share")
+		}
+	} else {
+		var err error
+		if dir, err = os.UserConfigDir(); err != nil {
+			return ""
+		}
+	}
+	return filepath.Join(dir, "moonshot", "profile.json")
+}
+
+// LoadProfile loads the profile in file, or returns a new one if the file
+// does not exist. A profile without a file is never saved.
+func LoadProfile(file string) (*Profile, error) {
+	p := &Profile{file: file}
+	p.ResetProgress()
+	if file == "" {
+		return p, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	if p.Unlocked < 1 {
+		p.Unlocked = 1
+	}
+	if p.Levels == nil {
+		p.Levels = map[string]LevelResult{}
+	}
+	return p, nil
+}
+
+// Save writes the profile to its file. The file is replaced atomically, so
+// it is never left half written.
+func (p *Profile) Save() error {
+	if p.file == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(p.file, data)
+}
+
+// ResetProgress forgets the unlocked levels, results, attempts and scores,
+// but keeps the settings.
+func (p *Profile) ResetProgress() {
+	p.Unlocked = 1
+	p.Attempts = 0
+	p.BestTotal = 0
+	p.Levels = map[string]LevelResult{}
+}
+
+// Failed records a failed attempt on level.
+func (p *Profile) Failed(level int) {
+	r := p.Levels[strconv.Itoa(level)]
+	r.Attempts++
+	p.Levels[strconv.Itoa(level)] = r
+	p.Attempts++
+}
+
+// Landed records a landing on level with its score, and the total score
+// of the run so far, and unlocks the next level.
+func (p *Profile) Landed(level int, l Landing, score, total int) {
+	r := p.Levels[strconv.Itoa(level)]
+	r.Attempts++
+	r.Landings++
+	if r.BestAttempts == 0 || l.Attempt < r.BestAttempts {
+		r.BestAttempts = l.Attempt
+	}
+	if r.BestTimeMs == 0 || l.TimeMs < r.BestTimeMs {
+		r.BestTimeMs = l.TimeMs
+	}
+	if score > r.BestScore {
+		r.BestScore = score
+	}
+	p.Levels[strconv.Itoa(level)] = r
+	p.Attempts++
+	if total > p.BestTotal {
+		p.BestTotal = total
+	}
+
+	if level+1 > p.Unlocked {
+		p.Unlocked = level + 1
+	}
+}
+
+// Result returns the record of level.
+func (p *Profile) Result(level int) LevelResult {
+	return p.Levels[strconv.Itoa(level)]
+}
+
+// sets returns the settings as path=value config settings.
+func (s Settings) sets() []string {
+	if s.Packs == nil {
+		return nil
+	}
+	return []string{"packs=" + strings.Join(s.Packs, ",")}
+}
+
+// writeFileAtomic writes data to a temporary file next to file and renames
+// it over file once it is complete.
+func writeFileAtomic(file string, data []byte) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), file)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"goo/loader"
+)
+
+// reloadPollMs is how often files are checked for changes, in wall time.
+const reloadPollMs = 500
+
+// Reloader watches the config file, the resource packs, the shaders, the
+// assets and the sounds, and reloads what changed while the game runs.
+// Problems are shown on screen and the game goes on with what it had.
+type Reloader struct {
+	game       *Game
+	packs      []*Pack
+	configFile string
+	required   bool
+	sets       []string
+	packSet    string
+	watcher    loader.Watcher
+	lastPoll   time.Time
+}
+
+// NewReloader creates a reloader for the game, which owns the open packs
+// from then on. The config is reloaded with the same arguments as
+// LoadConfiguration.
+func NewReloader(g *Game, packs []*Pack, configFile string, required bool, sets []string) *Reloader {
+	r := &Reloader{
+		game:       g,
+		packs:      packs,
+		configFile: configFile,
+		required:   required,
+		sets:       sets,
+		lastPoll:   time.Now(),
+	}
+	r.watch()
+	return r
+}
+
+func (r *Reloader) watch() {
+	r.watcher.WatchDisk(r.configFile)
+	for _, p := range r.game.conf.Packs {
+		r.watcher.WatchDisk(PackFile(p))
+	}
+	for _, m := range []map[string]string{r.game.conf.Shaders, r.game.conf.Assets, r.game.conf.Sounds} {
+		for _, f := range m {
+			r.watcher.Watch(f)
+		}
+	}
+}
+
+// Poll checks for changed files, at most every reloadPollMs.
+func (r *Reloader) Poll() {
+	if time.Since(r.lastPoll).Milliseconds() < reloadPollMs {
+		return
+	}
+	r.lastPoll = time.Now()
+
+	changed := map[string]bool{}
+	for _, f := range r.watcher.Changed() {
+		changed[f] = true
+	}
+	if len(changed) > 0 {
+		r.reload(changed, false)
+	}
+}
+
+// SetPacks switches to the named resource packs, overriding the packs in
+// the config, and reloads everything.
+func (r *Reloader) SetPacks(names []string) {
+	r.packSet = "packs=" + strings.Join(names, ",")
+	r.reload(map[string]bool{}, true)
+}
+
+// reload reloads the changed files, or every file if all is set.
+func (r *Reloader) reload(changed map[string]bool, all bool) {
+	errs := []error{}
+	old := r.game.conf
+	conf := old
+
+	for _, p := range old.Packs {
+		all = all || changed[PackFile(p)]
+	}
+	if all || changed[r.configFile] {
+		sets := r.sets
+		if r.packSet != "" {
+			sets = append(append([]string{}, sets...), r.packSet)
+		}
+		c, packs, err := LoadConfiguration(r.configFile, r.required, sets)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			conf = c
+			all = all || strings.Join(c.Packs, ",") != strings.Join(old.Packs, ",")
+			ClosePacks(r.packs)
+			r.packs = packs
+			loader.Files.Packs = packFS(packs)
+		}
+	}
+
+	// Sprites are rebuilt from the new file if the asset now points at
+	// another file, or from the same file if it changed.
+	sprites := map[string]string{}
+	for k, f := range old.Assets {
+		if nf, ok := conf.Assets[k]; ok && nf != f {
+			sprites[f] = nf
+		} else if all || changed[f] {
+			sprites[f] = f
+		}
+	}
+
+	reloadShaders := all || len(conf.Shaders) != len(old.Shaders)
+	for k, f := range conf.Shaders {
+		if changed[f] || old.Shaders[k] != f {
+			reloadShaders = true
+		}
+	}
+
+	// The collision matrix of the level being played is rebuilt with the
+	// level's entries on top, as when it started. A broken one is not
+	// taken, so the next level can still start.
+	if !reflect.DeepEqual(conf.Collisions, old.Collisions) {
+		scene := r.game.scene
+		if c, err := NewCollisions(conf.Collisions, scene.gameMap.level.Collisions); err != nil {
+			errs = append(errs, fmt.Errorf("collisions.%v", err))
+			conf.Collisions = old.Collisions
+		} else {
+			scene.collisions = c
+		}
+	}
+
+	r.game.conf = conf
+	if all {
+		// Files may now come from other packs, start over.
+		r.watcher = loader.Watcher{}
+	}
+	r.watch()
+
+	if reloadShaders {
+		if err := r.game.renderer.LoadShaders(conf.Shaders); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(sprites) > 0 {
+		errs = append(errs, r.game.scene.ReloadSprites(sprites)...)
+	}
+	for k, f := range conf.Sounds {
+		if !all && !changed[f] && old.Sounds[k] == f {
+			continue
+		}
+		if err := r.game.sound.Load(f, k); err != nil {
+			errs = append(errs, err)
+		} else if k == "bgmusic" {
+			r.game.sound.Play(k, 0.3)
+		}
+	}
+
+	if len(errs) > 0 {
+		msg := "Reload failed:"
+		for _, err := range errs {
+			msg += fmt.Sprintf("\n%v", err)
+		}
+		r.game.ShowError(msg)
+	} else {
+		r.game.ShowError("")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadCollisions(t *testing.T) {
+	g := newTestGame(t, false)
+	g.scene.gameMap.StartLevel(1)
+	file := filepath.Join(t.TempDir(), "config.json")
+	r := NewReloader(g, nil, file, false, nil)
+	reload := func(config string) {
+		if err := os.WriteFile(file, []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		r.reload(map[string]bool{file: true}, false)
+	}
+
+	reload(`{"collisions": {"shot_alien": "explode"}}`)
+	if g.errText != "" {
+		t.Fatalf("reload failed: %s", g.errText)
+	}
+	if c := g.scene.collisions.Response(ObjectShot, ObjectAlien); c != CollideExplode {
+		t.Errorf("shot hitting an alien: %s, want explode", c)
+	}
+
+	// A broken matrix is not taken, the next level must still start.
+	reload(`{"collisions": {"shot_alien": "vanish"}}`)
+	if g.errText == "" {
+		t.Errorf("reload of a broken collision matrix gave no error")
+	}
+	if c := g.scene.collisions.Response(ObjectShot, ObjectAlien); c != CollideExplode {
+		t.Errorf("shot hitting an alien: %s, want explode", c)
+	}
+	if c := g.conf.Collisions["shot_alien"]; c != "explode" {
+		t.Errorf("config collisions.shot_alien = %q, want explode", c)
+	}
+	g.scene.gameMap.StartLevel(2)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+)
+
+const replayMagic = "MSR2"
+
+// replayMagic1 is the format before flags, which replays without flight
+// mode.
+const replayMagic1 = "MSR1"
+
+// Replay flags, for the settings a run is played with.
+const (
+	replayFlightMode = 1 << iota
+)
+
+type stepAction struct {
+	step   int
+	action Action
+}
+
+// Replay is a recorded run: the seed, the level it started on, whether it
+// was flown in flight mode, the number of simulation steps and the actions
+// applied in each step. The simulation is deterministic, but it also
+// depends on the levels and parts of the config, which are too big to
+// store. Hash is the RunHash of those when the run was recorded, so a
+// replay played with other ones can be told apart. It is 0 for replays
+// from before it was stored.
+//
+// On disk it is the magic followed by varints: seed, level, flags, hash,
+// steps and the number of actions, then for each action the steps since
+// the previous one, the type and any arguments.
+type Replay struct {
+	Seed       int64
+	Level      int
+	FlightMode bool
+	Hash       uint64
+	Steps      int
+	actions    []stepAction
+}
+
+// RunHash returns a hash of what a run depends on besides the seed, the
+// level and flight mode: the collision matrix and the asset files of the
+// config, and the levels with their landing limits and collisions. The
+// contents of the asset files are not part of it.
+func RunHash(conf Config, levels []Level) uint64 {
+	h := fnv.New64a()
+	enc := json.NewEncoder(h)
+	// Maps are encoded with sorted keys, so the hash is stable. Plain
+	// maps and structs always encode.
+	enc.Encode(conf.Collisions)
+	enc.Encode(conf.Assets)
+	enc.Encode(levels)
+	return h.Sum64()
+}
+
+func (r *Replay) Add(step int, a Action) {
+	r.actions = append(r.actions, stepAction{step: step, action: a})
+}
+
+func SaveReplay(file string, r *Replay) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	buf := make([]byte, binary.MaxVarintLen64)
+	putUvarint := func(v uint64) {
+		w.Write(buf[:binary.PutUvarint(buf, v)])
+	}
+	putVarint := func(v int64) {
+		w.Write(buf[:binary.PutVarint(buf, v)])
+	}
+
+	w.WriteString(replayMagic)
+	putVarint(r.Seed)
+	putUvarint(uint64(r.Level))
+	flags := 0
+	if r.FlightMode {
+		flags |= replayFlightMode
+	}
+	putUvarint(uint64(flags))
+	putUvarint(r.Hash)
+	putUvarint(uint64(r.Steps))
+	putUvarint(uint64(len(r.actions)))
+
+	last := 0
+	for _, sa := range r.actions {
+		putUvarint(uint64(sa.step - last))
+		last = sa.step
+		w.WriteByte(byte(sa.action.Type))
+		switch sa.action.Type {
+		case ActionStartLevel:
+			putUvarint(uint64(sa.action.Level))
+		case ActionExplode:
+			putVarint(int64(sa.action.X))
+			putVarint(int64(sa.action.Y))
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func LoadReplay(file string) (*Replay, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	magic := make([]byte, len(replayMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != replayMagic && string(magic) != replayMagic1 {
+		return nil, fmt.Errorf("%s: not a replay file", file)
+	}
+
+	var rerr error
+	uvarint := func() int {
+		v, err := binary.ReadUvarint(r)
+		if err != nil && rerr == nil {
+			rerr = err
+		}
+		return int(v)
+	}
+	varint := func() int64 {
+		v, err := binary.ReadVarint(r)
+		if err != nil && rerr == nil {
+			rerr = err
+		}
+		return v
+	}
+
+	rp := &Replay{}
+	rp.Seed = varint()
+	rp.Level = uvarint()
+	if string(magic) != replayMagic1 {
+		flags := uvarint()
+		if flags&^replayFlightMode != 0 && rerr == nil {
+			return nil, fmt.Errorf("%s: unknown flags %#x", file, flags)
+		}
+		rp.FlightMode = flags&replayFlightMode != 0
+		h, err := binary.ReadUvarint(r)
+		if err != nil && rerr == nil {
+			rerr = err
+		}
+		rp.Hash = h
+	}
+	rp.Steps = uvarint()
+	count := uvarint()
+
+	step := 0
+	for i := 0; i < count && rerr == nil; i++ {
+		step += uvarint()
+		t, err := r.ReadByte()
+		if err != nil {
+			rerr = err
+			break
+		}
+		a := Action{Type: ActionType(t)}
+		switch a.Type {
+		case ActionBoost, ActionRelease, ActionRespawn, ActionThrust, ActionSteerLeft, ActionSteerRight, ActionRestartLevel:
+		case ActionStartLevel:
+			a.Level = uvarint()
+		case ActionExplode:
+			a.X = int(varint())
+			a.Y = int(varint())
+		default:
+			return nil, fmt.Errorf("%s: unknown action %d", file, t)
+		}
+		rp.Add(step, a)
+	}
+
+	if rerr != nil {
+		return nil, fmt.Errorf("%s: %v", file, rerr)
+	}
+	return rp, nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goo"
+	"goo/gfx"
+	"goo/loader"
+)
+
+func testReplay() *Replay {
+	r := &Replay{Seed: -42, Level: 3, FlightMode: true, Hash: 1 << 63, Steps: 100000}
+	r.Add(0, Action{Type: ActionStartLevel, Level: 3})
+	r.Add(0, Action{Type: ActionThrust})
+	r.Add(1, Action{Type: ActionSteerLeft})
+	r.Add(200, Action{Type: ActionSteerRight})
+	r.Add(200, Action{Type: ActionBoost})
+	r.Add(201, Action{Type: ActionRelease})
+	r.Add(5000, Action{Type: ActionExplode, X: -17, Y: 1200})
+	r.Add(70000, Action{Type: ActionRespawn})
+	r.Add(80000, Action{Type: ActionRestartLevel})
+	r.Add(99999, Action{Type: ActionStartLevel, Level: 10})
+	return r
+}
+
+func TestReplayRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		replay *Replay
+	}{
+		{"empty", &Replay{}},
+		{"actions", testReplay()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "run.replay")
+			if err := SaveReplay(file, tt.replay); err != nil {
+				t.Fatal(err)
+			}
+			r, err := LoadReplay(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r, tt.replay) {
+				t.Errorf("LoadReplay = %+v, want %+v", r, tt.replay)
+			}
+		})
+	}
+}
+
+func TestLoadReplayErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "run.replay")
+	if err := SaveReplay(file, testReplay()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]byte{
+		"empty":          {},
+		"bad header":     append([]byte("MSR0"), data[len(replayMagic):]...),
+		"unknown flags":  append([]byte(replayMagic), 0, 0, 2, 0, 0),
+		"unknown action": append([]byte(replayMagic), 0, 0, 0, 0, 0, 1, 0, 0xff),
+		"bad varint":     append([]byte(replayMagic), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff),
+	}
+	for n := 1; n < len(data); n++ {
+		tests[fmt.Sprint("truncated to ", n)] = data[:n]
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, "bad.replay")
+			if err := os.WriteFile(file, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if r, err := LoadReplay(file); err == nil {
+				t.Errorf("LoadReplay = %+v, want an error", r)
+			}
+		})
+	}
+
+	if _, err := LoadReplay(filepath.Join(dir, "missing.replay")); err == nil {
+		t.Errorf("LoadReplay of a missing file gave no error")
+	}
+}
+
+func TestLoadReplayMSR1(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "run.replay")
+	// Seed -1, level 3, 2 steps and a launch in step 1, without flags.
+	data := append([]byte("MSR1"), 1, 3, 2, 1, 1, byte(ActionRelease))
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadReplay(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Replay{Seed: -1, Level: 3, Steps: 2}
+	want.Add(1, Action{Type: ActionRelease})
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("LoadReplay = %+v, want %+v", r, want)
+	}
+}
+
+// newTestGame returns a game with the built-in config and levels, with
+// flight mode set as given.
+func newTestGame(t *testing.T, flightMode bool) *Game {
+	loader.Files = &loader.FS{Builtin: goo.Assets}
+	renderer := &gfx.SoftRenderer{Camera: newCamera()}
+	if err := renderer.Init(); err != nil {
+		t.Fatal(err)
+	}
+	var conf Config
+	if err := conf.decode(bytes.NewReader(goo.DefaultConfig)); err != nil {
+		t.Fatal(err)
+	}
+	conf.FlightMode = flightMode
+	levels, err := LoadLevels(conf.Levels, conf.Assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := LoadProfile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGame(renderer, conf, levels, profile, 7)
+}
+
+// runState is what a run ends with.
+type runState struct {
+	X, Y, VX, VY float64
+	Rotation     float32
+	FuelUsed     float64
+	Landed       bool
+	Level, Total int
+}
+
+func endState(s *Scene) runState {
+	r := s.rocket
+	return runState{
+		X: r.X, Y: r.Y, VX: r.VX, VY: r.VY,
+		Rotation: r.chunk.RotationDeg,
+		FuelUsed: r.FuelUsed(),
+		Landed:   r.landed,
+		Level:    s.gameMap.currentLevel,
+		Total:    s.gameMap.total,
+	}
+}
+
+func TestReplayFlightMode(t *testing.T) {
+	const steps = 260
+
+	g := newTestGame(t, true)
+	s := g.scene
+	rec := &Replay{Seed: 7, Level: 3}
+	s.input.Record(rec)
+	s.gameMap.StartLevel(3)
+	for i := 0; i < steps; i++ {
+		rocket := s.rocket
+		switch {
+		case !rocket.hasReleased && rocket.boost < 10:
+			s.input.Queue(Action{Type: ActionBoost})
+		case !rocket.hasReleased:
+			s.input.Queue(Action{Type: ActionRelease})
+		case i%3 == 0:
+			s.input.Queue(Action{Type: ActionThrust})
+		case i%10 == 1:
+			s.input.Queue(Action{Type: ActionSteerLeft})
+		}
+		s.Update(stepMs)
+	}
+	want := endState(s)
+	if want.FuelUsed <= 10 {
+		t.Fatalf("fuel used = %v, want burns after the launch", want.FuelUsed)
+	}
+
+	file := filepath.Join(t.TempDir(), "run.replay")
+	if err := SaveReplay(file, rec); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadReplay(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.FlightMode {
+		t.Fatalf("replay without flight mode")
+	}
+
+	// The default config is without flight mode, the replay brings its own.
+	play := func(r *Replay) runState {
+		g := newTestGame(t, false)
+		s := g.scene
+		s.input.Play(r)
+		s.gameMap.StartLevel(r.Level)
+		for i := 0; i < r.Steps; i++ {
+			s.Update(stepMs)
+		}
+		return endState(s)
+	}
+	if got := play(r); got != want {
+		t.Errorf("replay ended with %+v, want %+v", got, want)
+	}
+	r.FlightMode = false
+	if got := play(r); got == want {
+		t.Errorf("replay without flight mode ended the same, %+v", got)
+	}
+}
+
+func TestRunHash(t *testing.T) {
+	conf := Config{
+		Collisions: map[string]string{"shot": "explode"},
+		Assets:     map[string]string{"rocket": "rocket.png"},
+	}
+	levels := []Level{{Name: "one", LandingMaxSpeed: 40}}
+	h := RunHash(conf, levels)
+	if h != RunHash(conf, []Level{{Name: "one", LandingMaxSpeed: 40}}) {
+		t.Errorf("RunHash differs for the same levels")
+	}
+
+	other := conf
+	other.FlightMode = true
+	other.KeyMenu = "GLFW_KEY_Q"
+	if RunHash(other, levels) != h {
+		t.Errorf("RunHash depends on values that don't change the run")
+	}
+
+	tests := map[string]func() (Config, []Level){
+		"collisions": func() (Config, []Level) {
+			c := conf
+			c.Collisions = map[string]string{"shot": "ignore"}
+			return c, levels
+		},
+		"assets": func() (Config, []Level) {
+			c := conf
+			c.Assets = map[string]string{"rocket": "other.png"}
+			return c, levels
+		},
+		"landing": func() (Config, []Level) {
+			return conf, []Level{{Name: "one", LandingMaxSpeed: 50}}
+		},
+		"level collisions": func() (Config, []Level) {
+			return conf, []Level{{Name: "one", LandingMaxSpeed: 40, Collisions: map[string]string{"alien": "ignore"}}}
+		},
+	}
+	for name, change := range tests {
+		if RunHash(change()) == h {
+			t.Errorf("RunHash ignores a change of %s", name)
+		}
+	}
+}
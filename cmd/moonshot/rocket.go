@@ -0,0 +1,321 @@
+package main
+
+import (
+	"math"
+
+	"goo/gfx"
+	"goo/physics"
+)
+
+// landingBase is the share of the rocket, from the bottom, that it can
+// land on.
+const landingBase = 0.25
+
+const (
+	// launchSpeed is the speed at launch for each unit of fuel, in pixels
+	// per second.
+	launchSpeed = 27
+	// windAccel is the acceleration of each unit of wind, in pixels per
+	// second squared.
+	windAccel = 40
+	// failSpeed is how fast the rocket can fall before it tumbles.
+	failSpeed = 150
+)
+
+// In flight mode the fuel not used for the launch is kept for burns while
+// flying. A burn speeds the rocket up along its heading, and steering turns
+// it.
+const (
+	flightThrust         = 600 // acceleration while burning
+	flightFuelPerSec     = 10  // fuel used per second of burn
+	flightSteerDegPerSec = 90
+)
+
+type Rocket struct {
+	Object
+	released    bool
+	hasReleased bool
+	failed      bool
+	boost       float64
+	launchBoost float64
+	flightFuel  float64
+	burnedFuel  float64
+	thrust      bool
+	steer       float64
+	landed      bool
+	landX       float64
+	landY       float64
+	landSpeed   float64
+	landTilt    float64
+	landZone    LandingZone
+	boostFont   gfx.Font
+	maxBoost    float64
+	initSleepMs float64
+}
+
+func (r *Rocket) Init(s *Scene, x, y, z float64, img string, objType ObjectType) {
+	font, err := s.game.renderer.LoadFont(s.game.conf.Assets["menuFont"], int32(50))
+	if err != nil {
+		panic(err)
+	}
+	r.boostFont = font
+
+	r.Object.Init(s, x, y, z, img, objType)
+}
+
+func (r *Rocket) Update(dt float64) {
+	if r.removed {
+		return
+	}
+
+	// Wait a while before the rocket is ready, counted in game time.
+	if r.elapsed < r.initSleepMs {
+		r.elapsed += dt
+		return
+	}
+
+	if r.landed {
+		r.Active = false
+		r.X = r.scene.moon.X - r.landX
+		r.Y = r.scene.moon.Y - r.landY
+	} else {
+		if r.VY < -failSpeed && r.hasReleased {
+			r.chunk.RotationDeg += float32(dt)
+			r.scene.game.sound.Stop("liftoff")
+			r.failed = true
+		} else {
+			r.scene.Smoke(
+				r.chunk.X+(float64(r.chunk.SizeX)*float64(r.chunk.Scale)/2),
+				r.chunk.Y-10,
+				10,
+			)
+			// smoke
+			for i := 0; i < int(r.boost)*40; i++ {
+				color := r.scene.Rand.Float32() * 0xFFFF
+				r.scene.Particles.NewParticle(physics.Particle{
+					R:    color,
+					G:    color,
+					B:    color,
+					A:    color,
+					Size: float64(1 + r.scene.Rand.Intn(2)),
+					Phys: physics.Phys{
+						X:        r.chunk.X + (float64(r.chunk.SizeX) * float64(r.chunk.Scale) / 2),
+						Y:        r.chunk.Y + 2,
+						VY:       (r.boost/2 - r.scene.Rand.Float64()*r.boost) * 0.75,
+						VX:       (r.boost*2 - r.scene.Rand.Float64()*r.boost*4) * 3,
+						Life:     r.scene.Rand.Float64() * 1,
+						Buoyancy: 1.2,
+						Drag:     1,
+						Active:   true,
+					},
+				})
+			}
+			if r.boost > 0 || r.hasReleased {
+				for i := 0; i < 50; i++ {
+					// Fire jet
+					cr := float32(r.scene.Rand.Intn(0xFFFFFF))
+					cg := float32(r.scene.Rand.Intn(0x33555))
+					cb := float32(0)
+					ca := float32(0xFFF + r.scene.Rand.Intn(0xFFFFFF))
+					r.scene.Particles.NewParticle(physics.Particle{
+						R:    cr * 2,
+						G:    cg,
+						B:    cb,
+						A:    ca,
+						Size: float64(1 + r.scene.Rand.Intn(2)),
+						Phys: physics.Phys{
+							X:           r.chunk.X + (float64(r.chunk.SizeX) * float64(r.chunk.Scale) / 2),
+							Y:           r.chunk.Y - 3,
+							VY:          10 - r.scene.Rand.Float64()*20,
+							VX:          10 - r.scene.Rand.Float64()*20,
+							Life:        r.scene.Rand.Float64(),
+							Buoyancy:    0.9,
+							Restitution: 0.2,
+							Active:      true,
+						},
+					})
+
+					// Blue intensive jet
+					cr = 0
+					cg = float32(r.scene.Rand.Intn(0x33555))
+					cb = float32(r.scene.Rand.Intn(0xFFFFFF))
+					ca = float32(0xFFF + r.scene.Rand.Intn(0xFFFFFF))
+					r.scene.Particles.NewParticle(physics.Particle{
+						R:    cr * 2,
+						G:    cg,
+						B:    cb,
+						A:    ca,
+						Size: float64(1 + r.scene.Rand.Intn(2)),
+						Phys: physics.Phys{
+							X:           r.chunk.X + (float64(r.chunk.SizeX) * float64(r.chunk.Scale) / 2),
+							Y:           r.chunk.Y,
+							VY:          10 - r.scene.Rand.Float64()*20,
+							VX:          10 - r.scene.Rand.Float64()*20,
+							Life:        r.scene.Rand.Float64() / 4,
+							Buoyancy:    0.9,
+							Restitution: 0.2,
+							Active:      true,
+						},
+					})
+				}
+			}
+		}
+
+		if r.released {
+			r.scene.game.sound.Play("liftoff", 0.5)
+			r.VY = r.boost * launchSpeed
+			r.released = false
+			r.launchBoost = r.boost
+			r.boost = 0
+			if r.scene.flightMode {
+				r.flightFuel = r.maxBoost - r.launchBoost
+			}
+		} else if r.hasReleased && !r.failed {
+			r.fly(dt)
+		}
+		if r.hasReleased {
+			r.ApplyForce(r.scene.gameMap.Wind*windAccel*r.Mass, 0)
+		}
+
+		if (r.Collided() || r.Y < 1) && r.failed && r.hasReleased {
+			x, y := r.X, r.Y
+			if r.Collided() {
+				x, y, _ = r.Contact()
+			}
+			r.scene.Explode(x, y, 100)
+			r.Hit(int(x), int(y), ObjectWorld)
+		}
+	}
+
+	r.Object.Update(dt)
+
+	// Don't fall out of the map.
+	if r.Y < 0 {
+		r.Y = 0
+		r.Active = false
+	}
+}
+
+// fly turns and burns as asked for in this step.
+func (r *Rocket) fly(dt float64) {
+	// Positive rotation is counterclockwise.
+	r.chunk.RotationDeg -= float32(r.steer * flightSteerDegPerSec * dt / 1000)
+	if r.thrust && r.flightFuel > 0 {
+		heading := float64(r.chunk.RotationDeg) * math.Pi / 180
+		r.ApplyForce(-math.Sin(heading)*flightThrust*r.Mass, math.Cos(heading)*flightThrust*r.Mass)
+
+		burn := math.Min(flightFuelPerSec*dt/1000, r.flightFuel)
+		r.flightFuel -= burn
+		r.burnedFuel += burn
+	}
+	r.thrust = false
+	r.steer = 0
+}
+
+// canLand returns true if touching the moon at x, y is a landing: with the
+// base of the rocket, slow and upright enough for the level, and in a
+// landing zone if the moon requires it.
+func (r *Rocket) canLand(x, y int) bool {
+	l := r.scene.gameMap.level
+	moon := r.scene.moon
+
+	if r.failed {
+		return false
+	}
+	if float64(y)-r.Y >= float64(r.chunk.SizeY)*landingBase {
+		return false
+	}
+	if math.Hypot(r.VX, r.VY) > l.LandingMaxSpeed || tiltDeg(float64(r.chunk.RotationDeg)) > l.LandingMaxTilt {
+		return false
+	}
+	if _, ok := moon.zones.At(x-int(moon.X), y-int(moon.Y)); moon.zones.Required && !ok {
+		return false
+	}
+	return true
+}
+
+// tiltDeg returns how far a rotation is from upright, from 0 to 180.
+func tiltDeg(rotation float64) float64 {
+	t := math.Mod(math.Abs(rotation), 360)
+	if t > 180 {
+		t = 360 - t
+	}
+	return t
+}
+
+func (r *Rocket) Release() {
+	if r.boost > 0 {
+		r.scene.game.sound.Stop("thrusters")
+		r.released = true
+		r.hasReleased = true
+		r.Active = true
+		// Just so we don't explode at start if at bottom
+		r.Y += 1
+		r.VY = 0
+	}
+}
+
+// Thrust burns flight fuel in the next step.
+func (r *Rocket) Thrust() {
+	if r.hasReleased && !r.landed && !r.removed {
+		r.thrust = true
+	}
+}
+
+// Steer turns the rocket left, for a negative dir, or right in the next
+// step. It only turns while there is flight fuel left.
+func (r *Rocket) Steer(dir float64) {
+	if r.hasReleased && !r.landed && !r.removed && r.flightFuel > 0 {
+		r.steer = dir
+	}
+}
+
+// FuelUsed returns the fuel used for the launch and any burns.
+func (r *Rocket) FuelUsed() float64 {
+	return r.launchBoost + r.burnedFuel
+}
+
+func (r *Rocket) Boost() {
+	if !r.removed && !r.hasReleased && r.elapsed >= r.initSleepMs {
+		if r.boost == 0 {
+			r.scene.game.sound.Play("thrusters", 0.1)
+		}
+		if r.boost < r.maxBoost {
+			r.scene.game.sound.Volume("thrusters", r.boost*1/r.maxBoost)
+			r.boost += 0.2
+		}
+		if r.boost > r.maxBoost {
+			r.boost = r.maxBoost
+		}
+	}
+}
+
+func (r *Rocket) Hit(x, y int, objType ObjectType) {
+	if r.landed || r.removed {
+		return
+	}
+	c := r.collision(objType)
+	switch c {
+	case CollideIgnore:
+		return
+	case CollideDamage:
+		r.Explode(x, y)
+		return
+	}
+	if c == CollideLand && r.canLand(x, y) {
+		moon := r.scene.moon
+		r.landX = moon.X - float64(x)
+		r.landY = moon.Y - float64(y)
+		r.landSpeed = math.Hypot(r.VX, r.VY)
+		r.landTilt = tiltDeg(float64(r.chunk.RotationDeg))
+		r.landZone, _ = moon.zones.At(x-int(moon.X), y-int(moon.Y))
+		r.landed = true
+		r.scene.gameMap.Landed()
+		return
+	}
+	r.removed = true
+	r.Explode(int(r.X), int(r.Y))
+	r.scene.game.sound.Play("explosion1", 0.6)
+	r.scene.game.sound.Stop("liftoff")
+	r.scene.gameMap.Reset()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+
+	"goo/physics"
+)
+
+type Satellite struct {
+	Object
+	released bool
+	boost    float64
+	origX    float64
+	origY    float64
+}
+
+func (s *Satellite) Update(dt float64) {
+	if s.removed {
+		return
+	}
+
+	s.Active = false
+	s.Rotation += dt / (10000 + s.scene.Rand.Float64()*1000)
+	s.X = s.origX + 1000*math.Cos(s.Rotation)
+	s.Y = s.origY + 1000*math.Sin(s.Rotation)
+
+	for i := 0; i < 1; i++ {
+		cr := float32(0xFFFFFF)
+		cg := float32(0xFFFFFF)
+		cb := float32(0xFFFFFF)
+		ca := float32(0xFFF + s.scene.Rand.Intn(0xFFFFFF))
+		s.scene.Particles.NewParticle(physics.Particle{
+			R:    cr * 2,
+			G:    cg,
+			B:    cb,
+			A:    ca,
+			Size: float64(1 + s.scene.Rand.Intn(2)),
+			Phys: physics.Phys{
+				X:           5 - s.scene.Rand.Float64()*10 + s.chunk.X + (float64(s.chunk.SizeX) * float64(s.chunk.Scale) / 2),
+				Y:           5 - s.scene.Rand.Float64()*10 + s.chunk.Y + (float64(s.chunk.SizeY) * float64(s.chunk.Scale) / 2),
+				VY:          5 - s.scene.Rand.Float64()*10,
+				VX:          5 - s.scene.Rand.Float64()*10,
+				Life:        s.scene.Rand.Float64() / 3,
+				Buoyancy:    1,
+				Restitution: 0.2,
+				Active:      true,
+			},
+		})
+	}
+	s.Object.Update(dt)
+}
+
+func (s *Satellite) Hit(x, y int, objType ObjectType) {
+	switch s.collision(objType) {
+	case CollideExplode:
+		s.removed = true
+		s.Explode(int(s.X), int(s.Y))
+	case CollideDamage:
+		s.Explode(x, y)
+	}
+}
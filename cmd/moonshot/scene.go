@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"goo/gfx"
+	"goo/physics"
+	"goo/voxel"
+)
+
+// Scene is one simulation: the world, the objects in it, the level being
+// played and everything that drives it. Objects reach the scene they are
+// in through Object, so several scenes can run side by side.
+//
+// The embedded Space holds the world, the particles and the source of
+// all randomness in the scene. Rand is seeded from seed at the start of
+// every level, so a seed and a level number is enough to reproduce the
+// level layout, the wind and the simulation.
+//
+// flightMode is taken from the config when the scene is created and kept
+// for the whole run, like the seed, so replays play in the mode they were
+// recorded in.
+type Scene struct {
+	physics.Space
+	game       *Game
+	background *gfx.Background
+	objects    []Obj
+	moon       *Moon
+	rocket     *Rocket
+	gameMap    *Map
+	states     *StateMachine
+	scheduler  *Scheduler
+	input      *Input
+	broadphase Broadphase
+	collisions Collisions
+	seed       int64
+	flightMode bool
+}
+
+// NewScene creates an empty scene for the game. Sounds are played and
+// config is read through the game. The renderer must be initialized.
+func NewScene(g *Game, seed int64) *Scene {
+	s := &Scene{
+		Space: physics.Space{
+			World:     &voxel.World{},
+			Particles: &physics.ParticlePool{},
+			Rand:      rand.New(rand.NewSource(seed)),
+		},
+		game:       g,
+		background: &gfx.Background{},
+		moon:       &Moon{},
+		rocket:     &Rocket{},
+		gameMap:    &Map{},
+		states:     &StateMachine{},
+		scheduler:  &Scheduler{},
+		input:      &Input{},
+		seed:       seed,
+		flightMode: g.conf.FlightMode,
+	}
+	s.input.scene = s
+	s.Particles.Init(&s.Space, screenWidth, screenHeight)
+	s.gameMap.Init(s)
+	s.states.Init(s)
+	return s
+}
+
+// SeedLevel reseeds Rand for the given level.
+func (s *Scene) SeedLevel(level int) {
+	s.Rand.Seed(s.seed + int64(level))
+}
+
+// Update advances the simulation one step of dt milliseconds.
+func (s *Scene) Update(dt float64) {
+	s.input.Step()
+	s.scheduler.Update(dt)
+
+	for i := range s.objects {
+		s.objects[i].SavePosition()
+	}
+
+	s.DetectCollisions(dt)
+
+	if s.Rand.Intn(10) > 8 {
+		s.Star()
+	}
+
+	for i := range s.objects {
+		if s.objects[i].IsRemoved() {
+			continue
+		}
+		s.objects[i].Update(dt)
+	}
+
+	// Remove old objects
+	s.RemoveObjects()
+
+	s.Particles.Update(dt)
+}
+
+// Draw renders the scene and the HUD through the active renderer.
+// Objects are drawn alpha (0-1) of the way from the previous step.
+func (s *Scene) Draw(alpha float64) {
+	s.background.Draw(s.game.renderer)
+	for i := range s.objects {
+		s.objects[i].Draw(alpha)
+	}
+	s.World.Draw(s.game.renderer)
+	s.game.renderer.DrawParticles(s.Particles)
+
+	s.gameMap.Draw(alpha)
+}
+
+// ReloadSprites rebuilds the background and the sprites of the objects
+// loaded from any of files, which maps each file to the file to load.
+func (s *Scene) ReloadSprites(files map[string]string) []error {
+	errs := []error{}
+	if f, ok := files[s.background.File()]; ok {
+		if err := s.background.Init(f); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", f, err))
+		}
+		s.background.Clear(s.game.renderer)
+	}
+	for _, o := range s.objects {
+		if err := o.ReloadSprite(files); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (s *Scene) AddObject(o Obj) {
+	s.objects = append(s.objects, o)
+}
+
+func (s *Scene) RemoveObjects() {
+	removed := 0
+	for i := range s.objects {
+		n := i - removed
+		if s.objects[n].IsRemoved() {
+			s.objects[n].Clear()
+			s.objects[n] = s.objects[len(s.objects)-1]
+			s.objects[len(s.objects)-1] = nil
+			s.objects = s.objects[:len(s.objects)-1]
+			removed++
+		}
+	}
+}
+
+// DetectCollisions finds the objects touching each other and tells both
+// what they hit. The broadphase finds the pairs of objects that may touch,
+// and the border pixels of one of each pair are then checked against the
+// blocks of the other, unless the collision matrix ignores the pair.
+func (s *Scene) DetectCollisions(dt float64) {
+	objects := s.objects
+	bp := &s.broadphase
+
+	start := time.Now()
+	pairs := bp.Find(objects)
+	bp.BroadTime = time.Since(start)
+
+	start = time.Now()
+	bp.Pixels, bp.Hits = 0, 0
+	skip := false
+	for n := 0; n < len(pairs); n += 2 {
+		p1 := objects[pairs[n]]
+		p := objects[pairs[n+1]]
+		// An object removed by a hit is still hit by the rest of its pairs.
+		if n == 0 || pairs[n] != pairs[n-2] {
+			skip = p1.IsRemoved()
+		}
+		if skip || p.IsRemoved() || !s.collisions.Collides(p1.GetObjType(), p.GetObjType()) {
+			continue
+		}
+
+		// Sweep the border pixels along the last move relative to p1,
+		// so fast objects can't pass through it in one step.
+		mx, my := p.GetMove()
+		m1x, m1y := p1.GetMove()
+		mx, my = mx-m1x, my-m1y
+		still := mx == 0 && my == 0
+
+		hit := false
+		hx, hy, ht := 0, 0, 1.0
+		pbp := p.GetBorderPixels()
+		for i := 0; i < len(pbp); i += 2 {
+			x := p.GetX() + pbp[i]
+			y := p.GetY() + pbp[i+1]
+			bp.Pixels++
+
+			// Without a move there is nothing to sweep, the first pixel
+			// inside p1 is the contact.
+			if still {
+				if p1.IsActive(int(x), int(y)) {
+					hit, hx, hy = true, int(x), int(y)
+					break
+				}
+				continue
+			}
+
+			if bx, by, t, ok := voxel.Trace(x-mx, y-my, x, y, p1.IsActive); ok && (!hit || t < ht) {
+				hit, hx, hy, ht = true, bx, by, t
+			} else if !hit && p1.IsActive(int(x), int(y)) {
+				hit, hx, hy = true, int(x), int(y)
+			}
+			// Nothing comes before a contact at the start of the move.
+			if hit && ht == 0 {
+				break
+			}
+		}
+		if hit {
+			bp.Hits++
+			p.Hit(hx, hy, p1.GetObjType())
+			p1.Hit(hx, hy, p.GetObjType())
+		}
+	}
+	bp.NarrowTime = time.Since(start)
+}
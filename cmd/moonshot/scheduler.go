@@ -0,0 +1,73 @@
+package main
+
+type TimerID int
+
+type timer struct {
+	id    TimerID
+	at    float64
+	every float64
+	fn    func()
+}
+
+// Scheduler runs callbacks after a delay or repeatedly, counted in game
+// time. It is updated from the simulation step, so callbacks run on the
+// main thread and are as deterministic as the rest of the simulation.
+type Scheduler struct {
+	now    float64
+	nextID TimerID
+	timers []*timer
+}
+
+// After runs fn once, ms milliseconds from now.
+func (s *Scheduler) After(ms float64, fn func()) TimerID {
+	return s.add(ms, 0, fn)
+}
+
+// Every runs fn every ms milliseconds until cancelled.
+func (s *Scheduler) Every(ms float64, fn func()) TimerID {
+	return s.add(ms, ms, fn)
+}
+
+func (s *Scheduler) add(ms, every float64, fn func()) TimerID {
+	s.nextID++
+	s.timers = append(s.timers, &timer{id: s.nextID, at: s.now + ms, every: every, fn: fn})
+	return s.nextID
+}
+
+func (s *Scheduler) Cancel(id TimerID) {
+	for i, t := range s.timers {
+		if t.id == id {
+			s.timers = append(s.timers[:i], s.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// CancelAll cancels every timer, e.g. when the level changes.
+func (s *Scheduler) CancelAll() {
+	s.timers = nil
+}
+
+// Update advances the time and runs the callbacks that are due, in the
+// order they are due. Callbacks may add or cancel timers.
+func (s *Scheduler) Update(dt float64) {
+	s.now += dt
+	for {
+		var next *timer
+		for _, t := range s.timers {
+			if t.at <= s.now && (next == nil || t.at < next.at || (t.at == next.at && t.id < next.id)) {
+				next = t
+			}
+		}
+		if next == nil {
+			return
+		}
+
+		if next.every > 0 {
+			next.at += next.every
+		} else {
+			s.Cancel(next.id)
+		}
+		next.fn()
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"goo/sound"
+)
+
+func TestSchedulerOrder(t *testing.T) {
+	s := &Scheduler{}
+	ran := []string{}
+	run := func(name string) func() {
+		return func() { ran = append(ran, name) }
+	}
+
+	s.After(100, run("a"))
+	s.After(50, run("b"))
+	s.After(100, run("c"))
+	s.Every(50, run("d"))
+	s.After(0, func() {
+		ran = append(ran, "e")
+		// Due at once, after the timers added before it.
+		s.After(0, run("f"))
+	})
+	s.After(150, run("g"))
+
+	tests := []struct {
+		dt  float64
+		ran []string
+	}{
+		{0, []string{"e", "f"}},
+		{49, []string{}},
+		{1, []string{"b", "d"}},
+		// Several steps' worth of timers run in the order they are due.
+		{100, []string{"a", "c", "d", "d", "g"}},
+		{50, []string{"d"}},
+	}
+	for i, tt := range tests {
+		ran = []string{}
+		s.Update(tt.dt)
+		if !reflect.DeepEqual(ran, tt.ran) {
+			t.Errorf("update %d: ran %v, want %v", i, ran, tt.ran)
+		}
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := &Scheduler{}
+	ran := []string{}
+	run := func(name string) func() {
+		return func() { ran = append(ran, name) }
+	}
+
+	a := s.After(100, run("a"))
+	s.After(100, run("b"))
+	var c TimerID
+	c = s.Every(30, func() {
+		ran = append(ran, "c")
+		s.Cancel(c)
+	})
+	d := s.After(100, run("d"))
+	s.After(50, func() {
+		ran = append(ran, "e")
+		s.Cancel(d)
+	})
+	s.Cancel(a)
+
+	s.Update(200)
+	if want := []string{"c", "e", "b"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+
+	ran = []string{}
+	s.After(10, run("f"))
+	s.Every(10, run("g"))
+	s.CancelAll()
+	s.Update(100)
+	if len(ran) != 0 {
+		t.Errorf("ran %v after CancelAll", ran)
+	}
+	// Cancelling a timer twice is a no-op.
+	s.Cancel(a)
+}
+
+func TestSchedulerPaused(t *testing.T) {
+	sm := &StateMachine{}
+	sm.Init(&Scene{game: &Game{menu: &Menu{}, sound: &sound.Sound{}}})
+	s := &Scheduler{}
+	ran := 0
+	s.After(110, func() { ran++ })
+	step := func() { s.Update(stepMs) }
+
+	tests := []struct {
+		state   GameState
+		frameMs float64
+		now     float64
+		ran     int
+	}{
+		{StatePlaying, 55, 3 * stepMs, 0},
+		{StatePaused, 250, 3 * stepMs, 0},
+		{StateMenu, 250, 3 * stepMs, 0},
+		{StatePlaying, 55, 6 * stepMs, 0},
+		{StatePaused, 250, 6 * stepMs, 0},
+		{StatePlaying, 10, 7 * stepMs, 1},
+	}
+	acc := 0.0
+	for i, tt := range tests {
+		if !sm.Set(tt.state) {
+			t.Fatalf("frame %d: can't change to %v", i, tt.state)
+		}
+		acc = sm.Advance(acc, tt.frameMs, step)
+		if math.Abs(s.now-tt.now) > 1e-9 || ran != tt.ran {
+			t.Errorf("frame %d, %v: time %v, ran %d, want %v, %d", i, tt.state, s.now, ran, tt.now, tt.ran)
+		}
+	}
+}
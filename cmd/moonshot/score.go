@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math"
+)
+
+// The most points given for each part of the score of a level.
+const (
+	scoreFuel     = 1000 // landing with an empty tank, less the share used
+	scoreAttempts = 1000 // landing on the first attempt
+	scoreTime     = 1000 // landing at once, less scoreTimePerSec a second
+	scoreAlien    = 250  // each alien destroyed
+	scoreLanding  = 1000 // a soft and upright landing
+
+	scoreTimePerSec = 20
+)
+
+// Landing is how a level was landed.
+type Landing struct {
+	// Fuel is the share of the tank used for the launch, from 0 to 1.
+	Fuel float64
+	// Attempt is the attempt landed on, out of Attempts.
+	Attempt  int
+	Attempts int
+	// TimeMs is the time since the level started.
+	TimeMs float64
+	Aliens int
+	// Speed and TiltDeg are of the rocket as it touched the moon, out of
+	// the most allowed on the level.
+	Speed      float64
+	TiltDeg    float64
+	MaxSpeed   float64
+	MaxTiltDeg float64
+	// Zone is the landing zone landed in, if any.
+	Zone LandingZone
+}
+
+// Score is the score of a level, by part.
+type Score struct {
+	Fuel     int
+	Attempts int
+	Time     int
+	Aliens   int
+	Landing  int
+	Zone     int
+}
+
+func (l Landing) Score() Score {
+	quality := (1 - math.Min(l.Speed/l.MaxSpeed, 1)) * (1 - math.Min(l.TiltDeg/l.MaxTiltDeg, 1))
+
+	return Score{
+		Fuel:     int(math.Round(scoreFuel * (1 - math.Min(l.Fuel, 1)))),
+		Attempts: scoreAttempts * (l.Attempts - l.Attempt + 1) / l.Attempts,
+		Time:     int(math.Max(0, math.Round(scoreTime-l.TimeMs/1000*scoreTimePerSec))),
+		Aliens:   scoreAlien * l.Aliens,
+		Landing:  int(math.Round(scoreLanding * quality)),
+		Zone:     l.Zone.Bonus,
+	}
+}
+
+func (s Score) Total() int {
+	return s.Fuel + s.Attempts + s.Time + s.Aliens + s.Landing + s.Zone
+}
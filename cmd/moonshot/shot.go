@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+
+	"goo/physics"
+)
+
+type ShotType int
+
+const (
+	ShotRandom ShotType = iota
+	ShotStraight
+	ShotSeeking
+)
+
+var shotTypes = map[string]ShotType{
+	"random":   ShotRandom,
+	"straight": ShotStraight,
+	"seeking":  ShotSeeking,
+}
+
+// UnmarshalText parses shot types by name, as used in level files.
+func (t *ShotType) UnmarshalText(text []byte) error {
+	st, ok := shotTypes[string(text)]
+	if !ok {
+		return fmt.Errorf("unknown shot type %q", text)
+	}
+	*t = st
+	return nil
+}
+
+// shotWander is how hard random shots, and every shot once the rocket is
+// gone, are pushed around, in pixels per second squared.
+const shotWander = 200
+
+type Shot struct {
+	Object
+	Type    ShotType
+	Speed   float64
+	MaxTime float64
+	StartX  float64
+	StartY  float64
+}
+
+func (s *Shot) Init(sc *Scene, x, y, z float64, img string, objType ObjectType) {
+	sName := ""
+	switch s.Type {
+	case ShotRandom:
+		sName = "shot1"
+	case ShotStraight:
+		sName = "shot2"
+	case ShotSeeking:
+		sName = "shot3"
+	default:
+		sName = "shot1"
+	}
+
+	s.Object.Init(sc, x, y, z, sc.game.conf.Assets[sName], objType)
+}
+
+func (s *Shot) Update(dt float64) {
+	if s.removed {
+		return
+	}
+
+	// TBD: Configureable
+	if s.elapsed/1000 > s.MaxTime {
+		s.scene.Explode(s.X, s.Y, 30)
+		s.removed = true
+	}
+
+	for i := 0; i < 5; i++ {
+		cr := float32(0xFFFFF)
+		cg := float32(0xFFFFF)
+		cb := float32(0xFFFFF)
+		ca := float32(0xFFFFF)
+		s.scene.Particles.NewParticle(physics.Particle{
+			R:    cr,
+			G:    cg,
+			B:    cb,
+			A:    ca,
+			Size: float64(1 + s.scene.Rand.Intn(2)),
+			Phys: physics.Phys{
+				X:           2 - s.scene.Rand.Float64()*4 + s.chunk.X + float64(s.chunk.SizeX)/4,
+				Y:           2 - s.scene.Rand.Float64()*4 + s.chunk.Y + float64(s.chunk.SizeY)/4,
+				VY:          5 - s.scene.Rand.Float64()*10,
+				VX:          5 - s.scene.Rand.Float64()*10,
+				Life:        s.scene.Rand.Float64(),
+				Buoyancy:    1,
+				Restitution: 0.2,
+				Active:      true,
+			},
+		})
+	}
+
+	if s.scene.rocket.removed && !s.scene.rocket.hasReleased {
+		s.Explode(int(s.X), int(s.Y))
+		s.removed = true
+		s.Active = true
+	} else {
+		s.Lerp(dt)
+	}
+	s.Object.Update(dt)
+}
+
+func (s *Shot) Hit(x, y int, objType ObjectType) {
+	switch s.collision(objType) {
+	case CollideExplode:
+		s.removed = true
+		s.scene.Explode(s.X, s.Y, 30)
+		s.scene.game.sound.Play(fmt.Sprintf("explosion%d", 2+s.scene.Rand.Intn(2)), 0.5)
+	case CollideDamage:
+		s.Explode(x, y)
+	}
+}
+
+func (s *Shot) Lerp(dt float64) {
+	rocket := s.scene.rocket
+	if !rocket.removed && s.Type == ShotSeeking {
+		dist := math.Sqrt(math.Pow(rocket.X-s.X, 2) + math.Pow(rocket.Y-s.Y, 2))
+		v1 := mgl32.Vec3{float32(s.X), float32(s.Y), 0}
+		v2 := mgl32.Vec3{float32(rocket.X), float32(rocket.Y), 0}
+		q1 := mgl32.Quat{W: 0, V: v1}
+		q2 := mgl32.Quat{W: 0, V: v2}
+		q3 := mgl32.QuatLerp(q1, q2, float32(s.Speed)/float32(dist))
+		s.Kinematic = true
+		s.MoveTo(float64(q3.X()), float64(q3.Y()), dt)
+	} else if !rocket.removed && s.Type == ShotStraight {
+		// Initiate at first only
+		if s.StartX == 0 || s.StartY == 0 {
+			s.StartX = rocket.chunk.X
+			s.StartY = rocket.chunk.Y
+		}
+		s.Kinematic = true
+		dist := math.Sqrt(math.Pow(s.StartX-s.X, 2) + math.Pow(s.StartY-s.Y, 2))
+		v1 := mgl32.Vec3{float32(s.X), float32(s.Y), 0}
+		v2 := mgl32.Vec3{float32(s.StartX), float32(s.StartY), 0}
+		q1 := mgl32.Quat{W: 0, V: v1}
+		q2 := mgl32.Quat{W: 0, V: v2}
+		q3 := mgl32.QuatLerp(q1, q2, float32(s.Speed)/float32(dist))
+		s.MoveTo(float64(q3.X()), float64(q3.Y()), dt)
+	} else if !rocket.removed && s.Type == ShotRandom {
+		s.Rotation += dt / (s.scene.Rand.Float64() * 5000)
+		s.ApplyForce(math.Cos(s.Rotation)*shotWander*s.Mass, math.Sin(s.Rotation)*shotWander*s.Mass)
+	} else {
+		s.Kinematic = false
+		s.Rotation += dt / (s.scene.Rand.Float64() * 5000)
+		s.ApplyForce(math.Cos(s.Rotation)*shotWander*2*s.Mass, math.Sin(s.Rotation)*shotWander*2*s.Mass)
+	}
+
+}
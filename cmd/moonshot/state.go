@@ -0,0 +1,175 @@
+package main
+
+import (
+	"log"
+)
+
+type GameState int
+
+const (
+	StateMenu GameState = iota
+	StatePlaying
+	StatePaused
+	StateLevelComplete
+	StateFailed
+	StateVictory
+)
+
+var stateNames = map[GameState]string{
+	StateMenu:          "menu",
+	StatePlaying:       "playing",
+	StatePaused:        "paused",
+	StateLevelComplete: "level complete",
+	StateFailed:        "failed",
+	StateVictory:       "victory",
+}
+
+func (s GameState) String() string {
+	return stateNames[s]
+}
+
+// State decides what the game does while it is current: whether the
+// simulation runs, if the HUD is drawn, if gameplay keys are handled and
+// if the menu is shown. Next lists the states it may change to.
+type State struct {
+	Simulate bool
+	HUD      bool
+	Play     bool
+	Menu     bool
+	Next     []GameState
+	Enter    func()
+	Exit     func()
+}
+
+// StateMachine holds the current game state and runs the enter and exit
+// hooks on transitions.
+type StateMachine struct {
+	current  GameState
+	previous GameState
+	states   map[GameState]*State
+}
+
+func (sm *StateMachine) Init(s *Scene) {
+	menu := s.game.menu
+	sound := s.game.sound
+
+	sm.current = StateMenu
+	sm.previous = StateMenu
+	sm.states = map[GameState]*State{
+		StateMenu: {
+			Menu: true,
+			Next: []GameState{StatePlaying},
+			Enter: func() {
+				sound.StopAll()
+				menu.currentItem = 0
+			},
+			Exit: func() {
+				menu.showAbout = false
+				menu.showSelectLevel = false
+				menu.showPacks = false
+				menu.confirmReset = false
+			},
+		},
+		StatePlaying: {
+			Simulate: true,
+			HUD:      true,
+			Play:     true,
+			Next:     []GameState{StatePaused, StateLevelComplete, StateFailed},
+		},
+		StatePaused: {
+			HUD:  true,
+			Menu: true,
+			Next: []GameState{StateMenu, StatePlaying, StateLevelComplete, StateFailed, StateVictory},
+			Enter: func() {
+				sound.Pause()
+				menu.currentItem = 0
+			},
+			Exit: sound.Resume,
+		},
+		StateLevelComplete: {
+			Simulate: true,
+			HUD:      true,
+			Next:     []GameState{StatePaused, StatePlaying, StateVictory},
+			Enter:    s.gameMap.Completed,
+		},
+		StateFailed: {
+			Simulate: true,
+			HUD:      true,
+			Next:     []GameState{StatePaused, StateMenu, StatePlaying},
+			Enter:    s.gameMap.Failed,
+		},
+		StateVictory: {
+			Simulate: true,
+			Next:     []GameState{StatePaused, StateMenu, StatePlaying},
+			Enter:    s.gameMap.Victory,
+		},
+	}
+}
+
+// Set changes to state s. Changes that are not allowed from the current
+// state are logged and ignored, and false is returned.
+func (sm *StateMachine) Set(s GameState) bool {
+	if s == sm.current {
+		return true
+	}
+
+	if !sm.can(s) {
+		log.Printf("Ignoring state change from %v to %v", sm.current, s)
+		return false
+	}
+
+	if cur := sm.states[sm.current]; cur.Exit != nil {
+		cur.Exit()
+	}
+	sm.previous = sm.current
+	sm.current = s
+	if next := sm.states[s]; next.Enter != nil {
+		next.Enter()
+	}
+	return true
+}
+
+// Back returns to the state before the current one, if allowed. It is
+// used to resume after a pause, so the enter hook of the state returned
+// to is not run again.
+func (sm *StateMachine) Back() bool {
+	if sm.previous == sm.current || !sm.can(sm.previous) {
+		return false
+	}
+	if cur := sm.states[sm.current]; cur.Exit != nil {
+		cur.Exit()
+	}
+	sm.current, sm.previous = sm.previous, sm.current
+	return true
+}
+
+func (sm *StateMachine) can(s GameState) bool {
+	for _, n := range sm.states[sm.current].Next {
+		if n == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (sm *StateMachine) Is(s GameState) bool {
+	return sm.current == s
+}
+
+func (sm *StateMachine) Get() *State {
+	return sm.states[sm.current]
+}
+
+// Advance adds frameMs of real time to the time acc not simulated yet, if
+// the current state simulates, runs step for every stepMs of it and returns
+// what is left. Time doesn't pass in the other states, e.g. when paused.
+func (sm *StateMachine) Advance(acc, frameMs float64, step func()) float64 {
+	if sm.Get().Simulate {
+		acc += frameMs
+	}
+	for acc >= stepMs {
+		step()
+		acc -= stepMs
+	}
+	return acc
+}
@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/nullboundary/glfont"
+	"goo/gfx"
+	"goo/physics"
 )
 
 const (
@@ -19,13 +20,15 @@ type Stats struct {
 	avgDrawTime     time.Duration
 	avgChunkRebuild time.Duration
 	prevTime        time.Time
-	font            *glfont.Font
+	font            gfx.Font
+	game            *Game
 }
 
-func (s *Stats) Init() {
+func (s *Stats) Init(g *Game) {
+	s.game = g
 	s.fpsProbes = make([]int, maxFPSProbes)
 
-	font, err := glfont.LoadFont(conf.Assets["statsFont"], int32(12), screenWidth, screenHeight)
+	font, err := g.renderer.LoadFont(g.conf.Assets["statsFont"], int32(12))
 	if err != nil {
 		panic(err)
 	}
@@ -34,25 +37,31 @@ func (s *Stats) Init() {
 
 func (s *Stats) Draw() {
 	strs := []string{}
+	world := s.game.scene.World
+	particles := s.game.scene.Particles
 
 	tri := world.Triangles()
 	ab := world.ActiveBlocks()
 	geff := 100 - ((float64(tri) / float64(ab*2)) * 100.0)
-	tot := world.totalBlocks
+	tot := world.TotalBlocks()
 	if tot == 0 {
 		tot = 1
 	}
 
+	bp := s.game.scene.broadphase
+
 	strs = append(strs, []string{
 		fmt.Sprintf("FPS: %d", s.currFPS),
 		fmt.Sprintf("Avg. FPS: %d", s.avgFPS),
-		fmt.Sprintf("Chunks: %d", world.totalChunks),
+		fmt.Sprintf("Chunks: %d", world.TotalChunks()),
 		fmt.Sprintf("Dirty Chunks: %d", world.DirtyChunks()),
-		fmt.Sprintf("Triangles: %d", tri+particles.triangles),
+		fmt.Sprintf("Triangles: %d", tri+particles.Triangles()),
 		fmt.Sprintf("Active Blocks: %d (%d)", ab, int((ab/tot)*100)),
-		fmt.Sprintf("Total Blocks: %d", world.totalBlocks),
+		fmt.Sprintf("Total Blocks: %d", world.TotalBlocks()),
 		fmt.Sprintf("Greedy Efficiency: %f", geff),
-		fmt.Sprintf("Particles: %d/%d", particles.activeParticles, maxParticles),
+		fmt.Sprintf("Particles: %d/%d", particles.Active(), physics.MaxParticles),
+		fmt.Sprintf("Broadphase: %d objects, %d candidates, %d pairs (%v)", bp.Objects, bp.Candidates, bp.Pairs, bp.BroadTime.Round(time.Microsecond)),
+		fmt.Sprintf("Narrowphase: %d pixels, %d hits (%v)", bp.Pixels, bp.Hits, bp.NarrowTime.Round(time.Microsecond)),
 	}...)
 
 	s.font.SetColor(1.0, 1.0, 1.0, 1.0)
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"goo"
+	"goo/loader"
+)
+
+// gameKeys and menuKeys are the bindings handled together. A key may be
+// used once in each, e.g. enter both launches and selects in the menu.
+var (
+	gameKeys = []string{"keyLoadFuel", "keyDumpFuel", "keyRelease", "keyRespawn", "keyDebugInfo", "keyWireframe", "keyMenu", "keyPause", "keyThrust", "keySteerLeft", "keySteerRight"}
+	menuKeys = []string{"keyMenuUp", "keyMenuDown", "keyMenuSelect", "keyMenu", "keyPause"}
+)
+
+// runConfigCommand runs "moonshot config validate [file...]". Each file is
+// validated as a layer on top of the built-in config, without files the
+// built-in config, the user file and the MOONSHOT_* variables are. Every
+// problem is printed with its JSON path or variable, and the exit code is 1
+// if there were any.
+func runConfigCommand(args []string) int {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, "usage: moonshot config validate [file...]")
+		return 2
+	}
+
+	files := args[1:]
+	sources := map[string][]byte{}
+	if len(files) == 0 {
+		files = append(files, "built-in")
+		sources["built-in"] = goo.DefaultConfig
+		if f := UserConfigFile(); f != "" {
+			if _, err := os.Stat(f); err == nil {
+				files = append(files, f)
+			}
+		}
+	}
+
+	failed := false
+	for _, f := range files {
+		data, ok := sources[f]
+		if !ok {
+			var err error
+			if data, err = ioutil.ReadFile(f); err != nil {
+				fmt.Println(err)
+				failed = true
+				continue
+			}
+		}
+
+		errs := ValidateConfig(data)
+		for _, err := range errs {
+			fmt.Printf("%s: %v\n", f, err)
+		}
+		if len(errs) > 0 {
+			failed = true
+		} else {
+			fmt.Printf("%s: ok\n", f)
+		}
+	}
+
+	if len(args) == 1 && len(envVars(os.Environ())) > 0 {
+		errs := ValidateEnv(os.Environ())
+		for _, err := range errs {
+			fmt.Printf("environment: %v\n", err)
+		}
+		if len(errs) > 0 {
+			failed = true
+		} else {
+			fmt.Println("environment: ok")
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// ValidateEnv returns a problem for every MOONSHOT_* variable in environ
+// that is not a config value or can't be set to its value.
+func ValidateEnv(environ []string) []error {
+	errs := []error{}
+	var conf Config
+	if err := conf.decode(bytes.NewReader(goo.DefaultConfig)); err != nil {
+		return append(errs, fmt.Errorf("built-in config: %v", err))
+	}
+	for _, kv := range envVars(environ) {
+		path, ok := conf.envPath(strings.TrimPrefix(kv[0], envPrefix))
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown config value", kv[0]))
+			continue
+		}
+		if err := conf.Set(path, kv[1]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", kv[0], err))
+		}
+	}
+	return errs
+}
+
+// ValidateConfig checks a config file strictly and returns every problem
+// found, each prefixed with its JSON path. The file is checked for fields
+// that are not in Config, and after merging it with the built-in config,
+// for unknown key names, duplicate bindings and missing files.
+func ValidateConfig(data []byte) []error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		if serr, ok := err.(*json.SyntaxError); ok {
+			line := bytes.Count(data[:serr.Offset], []byte("\n")) + 1
+			return []error{fmt.Errorf("line %d: %v", line, err)}
+		}
+		return []error{err}
+	}
+
+	errs := checkJSON("", data, reflect.TypeOf(Config{}))
+
+	var conf Config
+	if err := conf.decode(bytes.NewReader(goo.DefaultConfig)); err != nil {
+		return append(errs, fmt.Errorf("built-in config: %v", err))
+	}
+	// Values of the wrong type are reported above and skipped, the rest
+	// is still decoded and can be validated.
+	if err := conf.decode(bytes.NewReader(data)); err != nil {
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			return append(errs, err)
+		}
+	}
+	return append(errs, conf.Validate()...)
+}
+
+// Validate checks the key bindings, the collision matrix and that the
+// files and packs the config refers to exist.
+func (c *Config) Validate() []error {
+	errs := []error{}
+
+	keys := c.keys()
+	for _, name := range sortedKeys(keys) {
+		if _, ok := GLKeys[keys[name]]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key name %q", name, keys[name]))
+		}
+	}
+	for _, group := range [][]string{gameKeys, menuKeys} {
+		bound := map[string]string{}
+		for _, name := range group {
+			k := keys[name]
+			if other, ok := bound[k]; ok && k != "" {
+				errs = append(errs, fmt.Errorf("%s: %s is already bound to %s", name, k, other))
+				continue
+			}
+			bound[k] = name
+		}
+	}
+
+	if st, err := loader.Files.Stat(c.Levels); err != nil || !st.IsDir() {
+		errs = append(errs, fmt.Errorf("levels: directory %q does not exist", c.Levels))
+	}
+	for i, p := range c.Packs {
+		if st, err := os.Stat(PackFile(p)); err != nil || st.IsDir() {
+			errs = append(errs, fmt.Errorf("packs[%d]: pack %q does not exist", i, PackFile(p)))
+		}
+	}
+	for _, m := range []struct {
+		path  string
+		files map[string]string
+	}{
+		{"assets", c.Assets},
+		{"sounds", c.Sounds},
+		{"shaders", c.Shaders},
+	} {
+		for _, k := range sortedKeys(m.files) {
+			if st, err := loader.Files.Stat(m.files[k]); err != nil || st.IsDir() {
+				errs = append(errs, fmt.Errorf("%s.%s: file %q does not exist", m.path, k, m.files[k]))
+			}
+		}
+	}
+	for _, err := range CheckCollisions(c.Collisions) {
+		errs = append(errs, fmt.Errorf("collisions.%v", err))
+	}
+	return errs
+}
+
+// keys returns the key bindings by JSON name.
+func (c *Config) keys() map[string]string {
+	keys := map[string]string{}
+	v := reflect.ValueOf(*c)
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonName(v.Type().Field(i))
+		if strings.HasPrefix(name, "key") {
+			keys[name] = v.Field(i).String()
+		}
+	}
+	return keys
+}
+
+// checkJSON checks that data can be decoded into a value of type t, with
+// every field name matching exactly.
+func checkJSON(path string, data []byte, t reflect.Type) []error {
+	errs := []error{}
+	at := func(format string, args ...interface{}) []error {
+		p := path
+		if p == "" {
+			p = "(root)"
+		}
+		return append(errs, fmt.Errorf("%s: %s", p, fmt.Sprintf(format, args...)))
+	}
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return at("expected an object")
+		}
+		fields := map[string]reflect.Type{}
+		for i := 0; i < t.NumField(); i++ {
+			fields[jsonName(t.Field(i))] = t.Field(i).Type
+		}
+		for _, k := range sortedKeys(obj) {
+			ft, ok := fields[k]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown field", join(k)))
+				continue
+			}
+			errs = append(errs, checkJSON(join(k), obj[k], ft)...)
+		}
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return at("expected an object")
+		}
+		for _, k := range sortedKeys(obj) {
+			errs = append(errs, checkJSON(join(k), obj[k], t.Elem())...)
+		}
+	case reflect.Slice:
+		var list []json.RawMessage
+		if err := json.Unmarshal(data, &list); err != nil {
+			return at("expected a list")
+		}
+		for i, e := range list {
+			errs = append(errs, checkJSON(fmt.Sprintf("%s[%d]", path, i), e, t.Elem())...)
+		}
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return at("expected a string")
+		}
+	case reflect.Bool:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return at("expected true or false")
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if err := json.Unmarshal(data, &f); err != nil {
+			return at("expected a number")
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of a map with string keys in order.
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goo/loader"
+	"goo/voxel"
+)
+
+// LandingZones are the marked parts of a moon to land on. They are read
+// from a file next to the moon sprite, e.g. moon1.zones.json for
+// moon1.png, and a moon without one can be landed on anywhere.
+type LandingZones struct {
+	// Required makes landings outside the zones fail.
+	Required bool          `json:"required"`
+	Zones    []LandingZone `json:"zones"`
+}
+
+// LandingZone is a rectangle in sprite pixels, from the bottom left
+// corner, and the bonus points for landing in it.
+type LandingZone struct {
+	Name   string `json:"name"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Bonus  int    `json:"bonus"`
+}
+
+// zonesFile returns the landing zones file of a sprite.
+func zonesFile(sprite string) string {
+	return strings.TrimSuffix(sprite, filepath.Ext(sprite)) + ".zones.json"
+}
+
+// LoadLandingZones loads the landing zones of a sprite, if it has any.
+func LoadLandingZones(sprite string) (LandingZones, error) {
+	var lz LandingZones
+
+	data, err := loader.Files.ReadFile(zonesFile(sprite))
+	if os.IsNotExist(err) {
+		return lz, nil
+	}
+	if err != nil {
+		return lz, err
+	}
+	if err := json.Unmarshal(data, &lz); err != nil {
+		return lz, fmt.Errorf("%s: %v", zonesFile(sprite), err)
+	}
+	return lz, nil
+}
+
+// At returns the zone that sprite pixel x, y is in.
+func (lz LandingZones) At(x, y int) (LandingZone, bool) {
+	for _, z := range lz.Zones {
+		if x >= z.X && x < z.X+z.Width && y >= z.Y && y < z.Y+z.Height {
+			return z, true
+		}
+	}
+	return LandingZone{}, false
+}
+
+// Mark tints the zones in chunk green, so they can be seen.
+func (lz LandingZones) Mark(c *voxel.Chunk) {
+	for _, z := range lz.Zones {
+		for x := z.X; x < z.X+z.Width; x++ {
+			for y := z.Y; y < z.Y+z.Height; y++ {
+				b := c.GetBlock(x, y, false)
+				if !b.Used {
+					continue
+				}
+				// Colors are premultiplied by alpha.
+				c.Add(x, y, b.R*0.4, b.G*0.4+b.A*0.6, b.B*0.4, b.A)
+			}
+		}
+	}
+}
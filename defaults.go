@@ -0,0 +1,18 @@
+// Package goo holds the files built into the game binary.
+package goo
+
+import (
+	"embed"
+)
+
+// DefaultConfig is gameconf.json, the configuration the game starts from
+// before any user settings are applied.
+//
+//go:embed gameconf.json
+var DefaultConfig []byte
+
+// Assets holds the assets directory: images, levels, fonts, shaders and
+// sounds.
+//
+//go:embed assets
+var Assets embed.FS
@@ -0,0 +1,39 @@
+package gfx
+
+import (
+	"image"
+
+	"goo/loader"
+)
+
+type Background struct {
+	image *image.RGBA
+	file  string
+}
+
+func (b *Background) Clear(r Renderer) {
+	r.ReleaseBackground(b)
+}
+
+// Init loads the image in file. If it fails the current image is kept.
+func (b *Background) Init(file string) error {
+	b.file = file
+	img, err := loader.LoadImage(file)
+	if err != nil {
+		return err
+	}
+	b.image = img
+	return nil
+}
+
+// File returns the image file the background was loaded from.
+func (b *Background) File() string {
+	return b.file
+}
+
+func (b *Background) Draw(r Renderer) {
+	if b.image == nil {
+		return
+	}
+	r.DrawBackground(b)
+}
@@ -0,0 +1,32 @@
+package gfx
+
+// The shaders glfont uses to draw text, which it only builds when loading
+// a font from a file on disk.
+const fontVertexShader = `#version 150 core
+
+in vec2 vert;
+in vec2 vertTexCoord;
+
+uniform vec2 resolution;
+
+out vec2 fragTexCoord;
+
+void main() {
+   // convert the rectangle from pixels to clip space
+   vec2 clipSpace = vert / resolution * 2.0 - 1.0;
+   fragTexCoord = vertTexCoord;
+   gl_Position = vec4(clipSpace * vec2(1, -1), 0, 1);
+}` + "\x00"
+
+const fontFragmentShader = `#version 150 core
+in vec2 fragTexCoord;
+out vec4 outputColor;
+
+uniform sampler2D tex;
+uniform vec4 textColor;
+
+void main()
+{
+    vec4 sampled = vec4(1.0, 1.0, 1.0, texture(tex, fragTexCoord).r);
+    outputColor = textColor * sampled;
+}` + "\x00"
@@ -0,0 +1,66 @@
+// Package gfx draws voxel chunks, backgrounds, particles and text with
+// OpenGL or in software.
+package gfx
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+
+	"goo/physics"
+	"goo/voxel"
+)
+
+// Renderer draws the game. All drawing goes through the active renderer,
+// so the same game code can draw with OpenGL, rasterize in software or
+// not draw at all.
+type Renderer interface {
+	Init() error
+	Clear()
+	SetWireframe(enabled bool)
+	DrawChunk(c *voxel.Chunk)
+	DrawBackground(b *Background)
+	DrawParticles(pp *physics.ParticlePool)
+	ReleaseChunk(c *voxel.Chunk)
+	ReleaseBackground(b *Background)
+	LoadFont(file string, scale int32) (Font, error)
+	LoadShaders(files map[string]string) error
+}
+
+// Camera is the size of the screen drawn to and the view and projection
+// the world is drawn with.
+type Camera struct {
+	Width      int
+	Height     int
+	View       mgl32.Mat4
+	Projection mgl32.Mat4
+}
+
+// Font draws text, with the y axis pointing down from the top of the screen.
+type Font interface {
+	SetColor(red, green, blue, alpha float32)
+	Printf(x, y float32, scale float32, fs string, argv ...interface{}) error
+}
+
+// NullRenderer draws nothing and is used when running headless.
+type NullRenderer struct{}
+
+func (r *NullRenderer) Init() error                               { return nil }
+func (r *NullRenderer) Clear()                                    {}
+func (r *NullRenderer) SetWireframe(enabled bool)                 {}
+func (r *NullRenderer) DrawChunk(c *voxel.Chunk)                  {}
+func (r *NullRenderer) DrawBackground(b *Background)              {}
+func (r *NullRenderer) DrawParticles(pp *physics.ParticlePool)    {}
+func (r *NullRenderer) ReleaseChunk(c *voxel.Chunk)               {}
+func (r *NullRenderer) ReleaseBackground(b *Background)           {}
+func (r *NullRenderer) LoadShaders(files map[string]string) error { return nil }
+
+func (r *NullRenderer) LoadFont(file string, scale int32) (Font, error) {
+	return &nullFont{}, nil
+}
+
+type nullFont struct{}
+
+func (f *nullFont) SetColor(red, green, blue, alpha float32) {}
+
+func (f *nullFont) Printf(x, y float32, scale float32, fs string, argv ...interface{}) error {
+	return nil
+}
@@ -0,0 +1,328 @@
+package gfx
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/all-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/nullboundary/glfont"
+
+	"goo/loader"
+	"goo/physics"
+	"goo/voxel"
+)
+
+// GLRenderer draws using OpenGL. It requires a current GL context.
+type GLRenderer struct {
+	Camera
+	Shaders map[string]string
+
+	shader         *Shader
+	bgShader       *Shader
+	particleShader *Shader
+	chunks         map[*voxel.Chunk]*glMesh
+	backgrounds    map[*Background]*glBackground
+	pbo            uint32
+	cbo            uint32
+	vao            uint32
+}
+
+type glMesh struct {
+	vbo     uint32
+	vao     uint32
+	ebo     uint32
+	version int
+	count   int32
+}
+
+type glBackground struct {
+	vbo     uint32
+	vao     uint32
+	ebo     uint32
+	texture Texture
+}
+
+func (r *GLRenderer) Init() error {
+	r.chunks = make(map[*voxel.Chunk]*glMesh)
+	r.backgrounds = make(map[*Background]*glBackground)
+
+	if err := r.LoadShaders(r.Shaders); err != nil {
+		return err
+	}
+
+	gl.GenVertexArrays(1, &r.vao)
+	gl.BindVertexArray(r.vao)
+
+	gl.GenBuffers(1, &r.pbo)
+	gl.GenBuffers(1, &r.cbo)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.pbo)
+	gl.BufferData(gl.ARRAY_BUFFER, physics.MaxParticles*4*4, nil, gl.STREAM_DRAW)
+	gl.VertexAttribPointer(0, 4, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(0)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.cbo)
+	gl.BufferData(gl.ARRAY_BUFFER, physics.MaxParticles*4*4, nil, gl.STREAM_DRAW)
+	gl.VertexAttribPointer(1, 4, gl.FLOAT, true, 0, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(1)
+
+	gl.VertexAttribDivisor(0, 1)
+	gl.VertexAttribDivisor(1, 1)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+
+	gl.Enable(gl.PROGRAM_POINT_SIZE)
+	gl.Enable(gl.DEPTH_TEST)
+	gl.DepthFunc(gl.LESS)
+	return nil
+}
+
+// LoadShaders compiles the shaders in files and replaces the current ones.
+// If any of them fails to compile the current shaders are kept.
+func (r *GLRenderer) LoadShaders(files map[string]string) error {
+	shaders := []*Shader{}
+	for _, name := range []string{"regular", "texture", "particle"} {
+		s, err := NewShader(files[name+"_vs"], files[name+"_fs"])
+		if err != nil {
+			for _, s := range shaders {
+				s.Delete()
+			}
+			return fmt.Errorf("%s shader: %v", name, err)
+		}
+		shaders = append(shaders, s)
+	}
+
+	for _, s := range []*Shader{r.shader, r.bgShader, r.particleShader} {
+		if s != nil {
+			s.Delete()
+		}
+	}
+	r.shader, r.bgShader, r.particleShader = shaders[0], shaders[1], shaders[2]
+	r.Shaders = files
+	return nil
+}
+
+func (r *GLRenderer) Clear() {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	gl.ClearColor(0.0, 0.0, 0.0, 1.0)
+}
+
+func (r *GLRenderer) SetWireframe(enabled bool) {
+	if enabled {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+	} else {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	}
+}
+
+func (r *GLRenderer) DrawChunk(c *voxel.Chunk) {
+	m, ok := r.chunks[c]
+	if !ok {
+		m = &glMesh{version: -1}
+		gl.GenVertexArrays(1, &m.vao)
+		gl.GenBuffers(1, &m.vbo)
+		gl.GenBuffers(1, &m.ebo)
+
+		gl.BindVertexArray(m.vao)
+
+		gl.BindBuffer(gl.ARRAY_BUFFER, m.vbo)
+		gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 7*4, gl.PtrOffset(0))
+		gl.EnableVertexAttribArray(0)
+
+		gl.VertexAttribPointer(1, 4, gl.FLOAT, false, 7*4, gl.PtrOffset(3*4))
+		gl.EnableVertexAttribArray(1)
+
+		gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, m.ebo)
+
+		gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+		gl.BindVertexArray(0)
+		r.chunks[c] = m
+	}
+
+	vertices, indices := c.Mesh()
+	if m.version != c.Version() {
+		m.version = c.Version()
+		m.count = int32(len(indices))
+		if m.count > 0 {
+			gl.BindBuffer(gl.ARRAY_BUFFER, m.vbo)
+			gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+
+			gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, m.ebo)
+			gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+		}
+	}
+	if m.count == 0 {
+		return
+	}
+
+	r.shader.Use()
+	r.setMatrices(r.shader, c.Model())
+
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+
+	gl.BindVertexArray(m.vao)
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, m.ebo)
+	gl.DrawElements(gl.TRIANGLES, m.count, gl.UNSIGNED_INT, gl.PtrOffset(0))
+	gl.BindVertexArray(0)
+}
+
+func (r *GLRenderer) ReleaseChunk(c *voxel.Chunk) {
+	m, ok := r.chunks[c]
+	if !ok {
+		return
+	}
+	gl.DeleteVertexArrays(1, &m.vao)
+	gl.DeleteBuffers(1, &m.vbo)
+	gl.DeleteBuffers(1, &m.ebo)
+	delete(r.chunks, c)
+}
+
+func (r *GLRenderer) DrawBackground(b *Background) {
+	bg, ok := r.backgrounds[b]
+	if !ok {
+		bg = r.newBackground(b)
+		r.backgrounds[b] = bg
+	}
+
+	bg.texture.Use()
+	r.bgShader.Use()
+
+	translate := mgl32.Translate3D(float32(r.Width)/2, float32(r.Height)/2, -1)
+	scale := mgl32.Scale3D(float32(r.Width), float32(r.Height), 1)
+	r.setMatrices(r.bgShader, translate.Mul4(scale))
+
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+
+	gl.BindVertexArray(bg.vao)
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, bg.ebo)
+	gl.DrawElements(gl.TRIANGLES, 6, gl.UNSIGNED_INT, gl.PtrOffset(0))
+	gl.BindVertexArray(0)
+}
+
+func (r *GLRenderer) newBackground(b *Background) *glBackground {
+	bg := &glBackground{}
+
+	vertices := []float32{
+		0.5, 0.5, 0.0, 1.0, 0.0, 0.0, 1.0, 1.0,
+		0.5, -0.5, 0.0, 0.0, 1.0, 0.0, 1.0, 0.0,
+		-0.5, -0.5, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0,
+		-0.5, 0.5, 0.0, 1.0, 1.0, 0.0, 0.0, 1.0,
+	}
+
+	indices := []uint32{
+		0, 1, 3,
+		1, 2, 3,
+	}
+
+	gl.GenVertexArrays(1, &bg.vao)
+	gl.GenBuffers(1, &bg.vbo)
+	gl.GenBuffers(1, &bg.ebo)
+
+	gl.BindVertexArray(bg.vao)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, bg.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, bg.ebo)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 8*4, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(0)
+
+	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, 8*4, gl.PtrOffset(3*4))
+	gl.EnableVertexAttribArray(1)
+
+	gl.VertexAttribPointer(2, 2, gl.FLOAT, false, 8*4, gl.PtrOffset(6*4))
+	gl.EnableVertexAttribArray(2)
+
+	bg.texture = NewTexture2D()
+	bg.texture.Use()
+	bg.texture.SetParameter(gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	bg.texture.SetParameter(gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	bg.texture.Upload(b.image, true)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+	return bg
+}
+
+func (r *GLRenderer) ReleaseBackground(b *Background) {
+	bg, ok := r.backgrounds[b]
+	if !ok {
+		return
+	}
+	gl.DeleteBuffers(1, &bg.vbo)
+	gl.DeleteVertexArrays(1, &bg.vao)
+	gl.DeleteBuffers(1, &bg.ebo)
+	if t, ok := bg.texture.(*Texture2D); ok {
+		gl.DeleteTextures(1, &t.ID)
+	}
+	delete(r.backgrounds, b)
+}
+
+func (r *GLRenderer) DrawParticles(pp *physics.ParticlePool) {
+	if pp.Active() > 0 {
+		gl.BindBuffer(gl.ARRAY_BUFFER, r.pbo)
+		gl.BufferData(gl.ARRAY_BUFFER, physics.MaxParticles*4*4, nil, gl.STREAM_DRAW)
+		gl.BufferSubData(gl.ARRAY_BUFFER, 0, pp.Active()*4*4, gl.Ptr(pp.Positions()))
+
+		gl.BindBuffer(gl.ARRAY_BUFFER, r.cbo)
+		gl.BufferData(gl.ARRAY_BUFFER, physics.MaxParticles*4*4, nil, gl.STREAM_DRAW)
+		gl.BufferSubData(gl.ARRAY_BUFFER, 0, pp.Active()*4*4, gl.Ptr(pp.Colors()))
+	}
+
+	r.particleShader.Use()
+
+	if err := r.particleShader.SetUniformMatrixName("projection", false, r.Projection); err != nil {
+		panic(err)
+	}
+
+	if err := r.particleShader.SetUniformMatrixName("view", false, r.View); err != nil {
+		panic(err)
+	}
+
+	gl.BindVertexArray(r.vao)
+	gl.DrawArraysInstanced(gl.POINTS, 0, 1, int32(pp.Active()))
+	gl.BindVertexArray(0)
+}
+
+// LoadFont loads a TrueType font like glfont.LoadFont does, but through
+// loader.Files.
+func (r *GLRenderer) LoadFont(file string, scale int32) (Font, error) {
+	f, err := loader.Files.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	program, err := newProgram(fontVertexShader, fontFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	gl.UseProgram(program)
+	resUniform := gl.GetUniformLocation(program, gl.Str("resolution\x00"))
+	gl.Uniform2f(resUniform, float32(r.Width), float32(r.Height))
+
+	font, err := glfont.LoadTrueTypeFont(program, f, scale, 32, 127, glfont.LeftToRight)
+	if err != nil {
+		return nil, err
+	}
+	return font, nil
+}
+
+func (r *GLRenderer) setMatrices(s *Shader, model mgl32.Mat4) {
+	if err := s.SetUniformMatrixName("model", false, model); err != nil {
+		panic(err)
+	}
+
+	if err := s.SetUniformMatrixName("projection", false, r.Projection); err != nil {
+		panic(err)
+	}
+
+	if err := s.SetUniformMatrixName("view", false, r.View); err != nil {
+		panic(err)
+	}
+}
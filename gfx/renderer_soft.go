@@ -0,0 +1,257 @@
+package gfx
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"goo/loader"
+	"goo/physics"
+	"goo/voxel"
+)
+
+// SoftRenderer rasterizes frames into an image.RGBA without a GPU, using
+// the same projection as the OpenGL renderer. Depth is tested against the
+// world z of each primitive, and colors are alpha blended.
+type SoftRenderer struct {
+	Camera
+	Frame     *image.RGBA
+	depth     []float32
+	transform mgl32.Mat4
+	fonts     map[string]*truetype.Font
+}
+
+func (r *SoftRenderer) Init() error {
+	r.Frame = image.NewRGBA(image.Rect(0, 0, r.Width, r.Height))
+	r.depth = make([]float32, r.Width*r.Height)
+	r.fonts = make(map[string]*truetype.Font)
+	return nil
+}
+
+func (r *SoftRenderer) Clear() {
+	r.transform = r.Projection.Mul4(r.View)
+	for i := 0; i < len(r.Frame.Pix); i += 4 {
+		r.Frame.Pix[i] = 0
+		r.Frame.Pix[i+1] = 0
+		r.Frame.Pix[i+2] = 0
+		r.Frame.Pix[i+3] = 0xFF
+	}
+	for i := range r.depth {
+		r.depth[i] = float32(math.Inf(-1))
+	}
+}
+
+func (r *SoftRenderer) SetWireframe(enabled bool)                 {}
+func (r *SoftRenderer) ReleaseChunk(c *voxel.Chunk)               {}
+func (r *SoftRenderer) ReleaseBackground(b *Background)           {}
+func (r *SoftRenderer) LoadShaders(files map[string]string) error { return nil }
+
+func (r *SoftRenderer) DrawChunk(c *voxel.Chunk) {
+	vertices, _ := c.Mesh()
+	mvp := r.transform.Mul4(c.Model())
+	z := float32(c.Z)
+
+	// Each block quad is four vertices of (x, y, z, r, g, b, a).
+	var p [4]mgl32.Vec2
+	for q := 0; q+28 <= len(vertices); q += 28 {
+		for i := 0; i < 4; i++ {
+			v := vertices[q+i*7:]
+			p[i] = r.project(mvp, v[0], v[1], v[2])
+		}
+		col := toRGBA(vertices[q+3], vertices[q+4], vertices[q+5], vertices[q+6])
+		r.fillTriangle(p[0], p[1], p[2], z, col)
+		r.fillTriangle(p[2], p[1], p[3], z, col)
+	}
+}
+
+func (r *SoftRenderer) DrawBackground(b *Background) {
+	model := mgl32.Translate3D(float32(r.Width)/2, float32(r.Height)/2, -1).Mul4(mgl32.Scale3D(float32(r.Width), float32(r.Height), 1))
+	mvp := r.transform.Mul4(model)
+	min := r.project(mvp, -0.5, 0.5, 0)
+	max := r.project(mvp, 0.5, -0.5, 0)
+
+	bounds := b.image.Bounds()
+	w := float64(max.X() - min.X())
+	h := float64(max.Y() - min.Y())
+	for y := int(min.Y()); y < int(max.Y()); y++ {
+		for x := int(min.X()); x < int(max.X()); x++ {
+			sx := bounds.Min.X + int(float64(x-int(min.X()))/w*float64(bounds.Dx()))
+			sy := bounds.Min.Y + int(float64(y-int(min.Y()))/h*float64(bounds.Dy()))
+			r.plot(x, y, -1, b.image.RGBAAt(sx, sy))
+		}
+	}
+}
+
+func (r *SoftRenderer) DrawParticles(pp *physics.ParticlePool) {
+	positions, colors := pp.Positions(), pp.Colors()
+	for i := 0; i < pp.Active(); i++ {
+		pos := positions[i*4:]
+		col := colors[i*4:]
+
+		// Same as the particle shader, the point size is taken from z.
+		p := r.project(r.transform, pos[0], pos[1], pos[2])
+		size := int(pos[2])
+		if size < 1 {
+			size = 1
+		}
+		c := toRGBA(col[0], col[1], col[2], col[3])
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				r.plot(int(p.X())-size/2+x, int(p.Y())-size/2+y, pos[2], c)
+			}
+		}
+	}
+}
+
+func (r *SoftRenderer) LoadFont(file string, scale int32) (Font, error) {
+	f, ok := r.fonts[file]
+	if !ok {
+		data, err := loader.Files.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		f, err = truetype.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		r.fonts[file] = f
+	}
+	return &softFont{r: r, font: f, scale: scale, color: color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}}, nil
+}
+
+// project transforms a point to screen coordinates, with y pointing down.
+func (r *SoftRenderer) project(mvp mgl32.Mat4, x, y, z float32) mgl32.Vec2 {
+	clip := mvp.Mul4x1(mgl32.Vec4{x, y, z, 1})
+	return mgl32.Vec2{
+		(clip.X()/clip.W() + 1) / 2 * float32(r.Width),
+		(1 - clip.Y()/clip.W()) / 2 * float32(r.Height),
+	}
+}
+
+func (r *SoftRenderer) fillTriangle(a, b, c mgl32.Vec2, z float32, col color.RGBA) {
+	minX := int(math.Floor(float64(min3(a.X(), b.X(), c.X()))))
+	maxX := int(math.Ceil(float64(max3(a.X(), b.X(), c.X()))))
+	minY := int(math.Floor(float64(min3(a.Y(), b.Y(), c.Y()))))
+	maxY := int(math.Ceil(float64(max3(a.Y(), b.Y(), c.Y()))))
+
+	area := edge(a, b, c)
+	if area == 0 {
+		return
+	}
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			p := mgl32.Vec2{float32(x) + 0.5, float32(y) + 0.5}
+			w0 := edge(b, c, p) / area
+			w1 := edge(c, a, p) / area
+			w2 := edge(a, b, p) / area
+			if w0 >= 0 && w1 >= 0 && w2 >= 0 {
+				r.plot(x, y, z, col)
+			}
+		}
+	}
+}
+
+// plot blends a pixel into the frame if it is not behind what is there.
+func (r *SoftRenderer) plot(x, y int, z float32, c color.RGBA) {
+	if x < 0 || y < 0 || x >= r.Width || y >= r.Height || c.A == 0 {
+		return
+	}
+	i := y*r.Width + x
+	if z < r.depth[i] {
+		return
+	}
+	r.depth[i] = z
+	r.blend(x, y, c)
+}
+
+func (r *SoftRenderer) blend(x, y int, c color.RGBA) {
+	o := r.Frame.PixOffset(x, y)
+	pix := r.Frame.Pix[o : o+4]
+	a := uint32(c.A)
+	pix[0] = uint8((uint32(c.R)*a + uint32(pix[0])*(0xFF-a)) / 0xFF)
+	pix[1] = uint8((uint32(c.G)*a + uint32(pix[1])*(0xFF-a)) / 0xFF)
+	pix[2] = uint8((uint32(c.B)*a + uint32(pix[2])*(0xFF-a)) / 0xFF)
+	pix[3] = 0xFF
+}
+
+type softFont struct {
+	r     *SoftRenderer
+	font  *truetype.Font
+	scale int32
+	color color.RGBA
+}
+
+func (f *softFont) SetColor(red, green, blue, alpha float32) {
+	f.color = toRGBA(red*0xFFFF, green*0xFFFF, blue*0xFFFF, alpha*0xFFFF)
+}
+
+func (f *softFont) Printf(x, y float32, scale float32, fs string, argv ...interface{}) error {
+	face := truetype.NewFace(f.font, &truetype.Options{
+		Size:    float64(f.scale) * float64(scale),
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	defer face.Close()
+
+	text := fmt.Sprintf(fs, argv...)
+	d := &font.Drawer{
+		Src:  image.Opaque,
+		Face: face,
+		Dot:  fixed.P(int(x), int(y)),
+	}
+	bounds, _ := d.BoundString(text)
+	mask := image.NewAlpha(image.Rect(
+		bounds.Min.X.Floor(), bounds.Min.Y.Floor(),
+		bounds.Max.X.Ceil(), bounds.Max.Y.Ceil(),
+	))
+	d.Dst = mask
+	d.DrawString(text)
+
+	// Text is drawn on top of everything, like the OpenGL font renderer.
+	b := mask.Bounds().Intersect(f.r.Frame.Bounds())
+	for py := b.Min.Y; py < b.Max.Y; py++ {
+		for px := b.Min.X; px < b.Max.X; px++ {
+			a := mask.AlphaAt(px, py).A
+			if a == 0 {
+				continue
+			}
+			c := f.color
+			c.A = uint8(uint32(c.A) * uint32(a) / 0xFF)
+			f.r.blend(px, py, c)
+		}
+	}
+	return nil
+}
+
+// toRGBA converts colors in the 0-0xFFFF range used by blocks and
+// particles, clamping like the shaders do.
+func toRGBA(r, g, b, a float32) color.RGBA {
+	clamp := func(v float32) uint8 {
+		v /= 0xFFFF
+		if v > 1 {
+			v = 1
+		} else if v < 0 {
+			v = 0
+		}
+		return uint8(v * 0xFF)
+	}
+	return color.RGBA{clamp(r), clamp(g), clamp(b), clamp(a)}
+}
+
+func edge(a, b, c mgl32.Vec2) float32 {
+	return (c.X()-a.X())*(b.Y()-a.Y()) - (c.Y()-a.Y())*(b.X()-a.X())
+}
+
+func min3(a, b, c float32) float32 {
+	return float32(math.Min(float64(a), math.Min(float64(b), float64(c))))
+}
+
+func max3(a, b, c float32) float32 {
+	return float32(math.Max(float64(a), math.Max(float64(b), float64(c))))
+}
@@ -1,13 +1,14 @@
-package main
+package gfx
 
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"strings"
 
 	"github.com/go-gl/gl/all-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
+
+	"goo/loader"
 )
 
 // Shader is a compiled shader program contains vertex and fragment shaders.
@@ -19,11 +20,11 @@ type Shader struct {
 
 // NewShader creates a shader program, it reads shader source from shader files.
 func NewShader(vertexFile, fragmentFile string) (*Shader, error) {
-	vertexSource, err := ioutil.ReadFile(vertexFile)
+	vertexSource, err := loader.Files.ReadFile(vertexFile)
 	if err != nil {
 		return nil, err
 	}
-	fragmentSource, err := ioutil.ReadFile(fragmentFile)
+	fragmentSource, err := loader.Files.ReadFile(fragmentFile)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +40,11 @@ func NewShader(vertexFile, fragmentFile string) (*Shader, error) {
 	}, nil
 }
 
+// Delete deletes the shader program.
+func (s *Shader) Delete() {
+	gl.DeleteProgram(s.ID)
+}
+
 // Use activates the shader
 func (s *Shader) Use() {
 	gl.UseProgram(s.ID)
@@ -1,22 +1,22 @@
-package main
+package gfx
 
 import (
 	"fmt"
 	"image"
 	"image/color"
-	"image/draw"
 	_ "image/jpeg"
 	_ "image/png"
 	"math/rand"
-	"os"
-	"time"
 
 	"github.com/go-gl/gl/all-core/gl"
+
+	"goo/loader"
 )
 
 type Texture interface {
 	SetParameter(uint32, interface{}) error
 	Load(file string, flipH, flipV bool) (*image.RGBA, error)
+	Upload(rgba *image.RGBA, flipV bool)
 	Use()
 }
 
@@ -56,23 +56,16 @@ func (texture *Texture2D) SetParameter(name uint32, param interface{}) error {
 }
 
 func (texture *Texture2D) Load(textureFile string, flipH, flipV bool) (*image.RGBA, error) {
-	f, err := os.Open(textureFile)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	src, _, err := image.Decode(f)
+	rgba, err := loader.LoadImage(textureFile)
 	if err != nil {
 		return nil, err
 	}
-	b := src.Bounds()
-	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
-	if rgba.Stride != rgba.Rect.Size().X*4 {
-		return nil, fmt.Errorf("unsupported stride %d", rgba.Stride)
-	}
-	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	texture.Upload(rgba, flipV)
+	return rgba, nil
+}
 
+// Upload copies the image into the texture, which must be bound.
+func (texture *Texture2D) Upload(rgba *image.RGBA, flipV bool) {
 	if flipV {
 		rgba = texture.flipV(rgba)
 	}
@@ -81,8 +74,6 @@ func (texture *Texture2D) Load(textureFile string, flipH, flipV bool) (*image.RG
 		0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(rgba.Pix))
 
 	gl.GenerateMipmap(gl.TEXTURE_2D)
-
-	return rgba, nil
 }
 
 func (texture *Texture2D) Use() {
@@ -112,15 +103,13 @@ func (texture *Texture2D) rgba2RGB(rgba *image.RGBA) []uint8 {
 	return rgb
 }
 
-func randomRGBA(sizeX, sizeY int) *image.RGBA {
-	rand.Seed(time.Now().UnixNano())
-
+func randomRGBA(rng *rand.Rand, sizeX, sizeY int) *image.RGBA {
 	rgba := image.NewRGBA(image.Rect(0, 0, sizeX, sizeY))
 	b := rgba.Bounds()
 	for y := 0; y < b.Dy(); y++ {
 		for x := 0; x < b.Dx(); x++ {
 			rgba.SetRGBA(x, y,
-				color.RGBA{uint8(rand.Intn(256)), uint8(rand.Intn(256)), uint8(rand.Intn(256)), 255})
+				color.RGBA{uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), 255})
 		}
 	}
 	return rgba
@@ -1,14 +1,15 @@
 module goo
 
-go 1.15
+go 1.16
 
 require (
 	github.com/faiface/beep v1.0.2
 	github.com/go-gl/gl v0.0.0-20190320180904-bf2b1f2f34d7
 	github.com/go-gl/glfw v0.0.0-20200707082815-5321531c36a2
 	github.com/go-gl/mathgl v0.0.0-20190713194549-592312d8590a
-	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
+	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/hajimehoshi/go-mp3 v0.3.1 // indirect
 	github.com/hajimehoshi/oto v0.6.7 // indirect
 	github.com/nullboundary/glfont v0.0.0-20201021194140-68bf63f50dcb
+	golang.org/x/image v0.0.0-20190321063152-3fc05d484e9f
 )
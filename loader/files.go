@@ -0,0 +1,118 @@
+package loader
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FS is the file system game files are loaded from. Files in Dir, if set,
+// take precedence over the ones in Packs, searched from the last to the
+// first, which take precedence over the ones in Builtin. This way single
+// files can be replaced without rebuilding. Absolute paths are read from
+// disk as they are.
+type FS struct {
+	Dir     string
+	Packs   []fs.FS
+	Builtin fs.FS
+}
+
+// Files is the file system all loaders read from. Without built-in files
+// it is the current directory.
+var Files = &FS{Builtin: os.DirFS(".")}
+
+// onDisk returns the path to read name from if it is not a built-in file.
+func (f *FS) onDisk(name string) (string, bool) {
+	if filepath.IsAbs(name) {
+		return name, true
+	}
+	if f.Dir == "" {
+		return "", false
+	}
+	p := filepath.Join(f.Dir, filepath.FromSlash(name))
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		return "", false
+	}
+	return p, true
+}
+
+// lookup returns the pack that has name, or the built-in files, and the
+// name to use in it.
+func (f *FS) lookup(name string) (fs.FS, string) {
+	name = filepath.ToSlash(filepath.Clean(name))
+	for i := len(f.Packs) - 1; i >= 0; i-- {
+		if _, err := fs.Stat(f.Packs[i], name); err == nil {
+			return f.Packs[i], name
+		}
+	}
+	return f.Builtin, name
+}
+
+func (f *FS) Open(name string) (fs.File, error) {
+	if p, ok := f.onDisk(name); ok {
+		return os.Open(p)
+	}
+	fsys, name := f.lookup(name)
+	return fsys.Open(name)
+}
+
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	if p, ok := f.onDisk(name); ok {
+		return os.ReadFile(p)
+	}
+	fsys, name := f.lookup(name)
+	return fs.ReadFile(fsys, name)
+}
+
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	if p, ok := f.onDisk(name); ok {
+		return os.Stat(p)
+	}
+	fsys, name := f.lookup(name)
+	return fs.Stat(fsys, name)
+}
+
+// ReadDir lists the built-in, pack and override files in a directory
+// together, sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if filepath.IsAbs(name) {
+		return os.ReadDir(name)
+	}
+
+	entries := map[string]fs.DirEntry{}
+	found, err := readDir(f.Builtin, filepath.ToSlash(filepath.Clean(name)), entries)
+	for _, p := range f.Packs {
+		if ok, _ := readDir(p, filepath.ToSlash(filepath.Clean(name)), entries); ok {
+			found = true
+		}
+	}
+	if f.Dir != "" {
+		onDisk, derr := readDir(os.DirFS(f.Dir), filepath.ToSlash(filepath.Clean(name)), entries)
+		if !onDisk && !os.IsNotExist(derr) {
+			return nil, derr
+		}
+		found = found || onDisk
+	}
+	if !found {
+		return nil, err
+	}
+
+	list := []fs.DirEntry{}
+	for _, e := range entries {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list, nil
+}
+
+func readDir(fsys fs.FS, name string, entries map[string]fs.DirEntry) (bool, error) {
+	list, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range list {
+		entries[e.Name()] = e
+	}
+	return true, nil
+}
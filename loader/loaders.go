@@ -1,10 +1,15 @@
-package main
+// Package loader loads images, objects and maps from files.
+package loader
 
 import (
+	"bytes"
+	"fmt"
 	"image"
+	"image/draw"
 	"image/jpeg"
 	"image/png"
-	"os"
+
+	"goo/voxel"
 )
 
 func LoadTexture(file string) (img image.Image, width, height, size float64, err error) {
@@ -15,20 +20,20 @@ func LoadTexture(file string) (img image.Image, width, height, size float64, err
 	image.RegisterFormat("png", "png", png.Decode, png.DecodeConfig)
 	image.RegisterFormat("jpg", "jpg", jpeg.Decode, jpeg.DecodeConfig)
 
-	imgfile, err := os.Open(file)
+	data, err := Files.ReadFile(file)
 	if err != nil {
 		return
 	}
 
-	defer imgfile.Close()
-
-	imgCfg, _, err := image.DecodeConfig(imgfile)
+	imgCfg, _, err := image.DecodeConfig(bytes.NewReader(data))
 	if err != nil {
 		return
 	}
 
-	imgfile.Seek(0, 0)
-	img, _, _ = image.Decode(imgfile)
+	img, _, err = image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
 
 	height = float64(imgCfg.Height)
 	width = float64(imgCfg.Width)
@@ -39,13 +44,34 @@ func LoadTexture(file string) (img image.Image, width, height, size float64, err
 	return
 }
 
-func LoadObject(file string, shader *Shader, z float64) Chunk {
+// LoadImage decodes an image file into RGBA.
+func LoadImage(file string) (*image.RGBA, error) {
+	f, err := Files.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	if rgba.Stride != rgba.Rect.Size().X*4 {
+		return nil, fmt.Errorf("unsupported stride %d", rgba.Stride)
+	}
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	return rgba, nil
+}
+
+func LoadObject(file string, z float64) (voxel.Chunk, error) {
 	img, width, height, _, err := LoadTexture(file)
 	if err != nil {
-		panic(err)
+		return voxel.Chunk{}, err
 	}
 
-	c := Chunk{shader: shader}
+	c := voxel.Chunk{}
 	c.Init(int(width+1), int(height+1), 100, 100, z, false)
 
 	for x := 0; x <= int(width); x++ {
@@ -55,10 +81,10 @@ func LoadObject(file string, shader *Shader, z float64) Chunk {
 		}
 	}
 
-	return c
+	return c, nil
 }
 
-func LoadMap(file string) error {
+func LoadMap(w *voxel.World, file string) error {
 	img, width, height, _, err := LoadTexture(file)
 	if err != nil {
 		return err
@@ -67,7 +93,7 @@ func LoadMap(file string) error {
 	for x := 0; x <= int(width); x++ {
 		for y := 0; y <= int(height); y++ {
 			r, g, b, a := img.At(x, int(height)-y).RGBA()
-			world.Add(x, y, float32(r), float32(g), float32(b), float32(a))
+			w.Add(x, y, float32(r), float32(g), float32(b), float32(a))
 		}
 	}
 
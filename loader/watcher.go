@@ -0,0 +1,71 @@
+package loader
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	disk    bool
+}
+
+// Watcher polls files for changes. A file counts as changed when its
+// modification time or size differs from the last poll, including when
+// it is created or removed.
+type Watcher struct {
+	files map[string]fileState
+}
+
+// Watch starts watching files, looked up through Files. Files already
+// watched keep their state.
+func (w *Watcher) Watch(files ...string) {
+	w.watch(false, files)
+}
+
+// WatchDisk starts watching files on disk, relative to the working
+// directory, such as the config file and the resource packs.
+func (w *Watcher) WatchDisk(files ...string) {
+	w.watch(true, files)
+}
+
+func (w *Watcher) watch(disk bool, files []string) {
+	if w.files == nil {
+		w.files = make(map[string]fileState)
+	}
+	for _, f := range files {
+		if _, ok := w.files[f]; !ok && f != "" {
+			w.files[f] = stat(f, disk)
+		}
+	}
+}
+
+// Changed returns the files that changed since the last call, in order.
+func (w *Watcher) Changed() []string {
+	changed := []string{}
+	for f, old := range w.files {
+		st := stat(f, old.disk)
+		if !st.modTime.Equal(old.modTime) || st.size != old.size {
+			w.files[f] = st
+			changed = append(changed, f)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func stat(file string, disk bool) fileState {
+	var st os.FileInfo
+	var err error
+	if disk {
+		st, err = os.Stat(file)
+	} else {
+		st, err = Files.Stat(file)
+	}
+	if err != nil {
+		return fileState{disk: disk}
+	}
+	return fileState{modTime: st.ModTime(), size: st.Size(), disk: disk}
+}
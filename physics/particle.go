@@ -0,0 +1,25 @@
+package physics
+
+type Particle struct {
+	Phys
+	Z    float32
+	R    float32
+	G    float32
+	B    float32
+	A    float32
+	Size float64
+}
+
+// Update particle
+func (p *Particle) Update(dt float64) {
+	p.Phys.Update(dt)
+}
+
+// Stop particle
+func (p *Particle) Stop() {
+	p.Life = 0
+	if p.Space.World.IsActive(int(p.X), int(p.Y-1)) {
+		p.Space.World.Add(int(p.X), int(p.Y), p.R, p.G, p.B, p.A)
+	}
+
+}
@@ -0,0 +1,102 @@
+package physics
+
+const (
+	MaxParticles = 100000
+)
+
+type ParticlePool struct {
+	space           *Space
+	width           float64
+	height          float64
+	particles       []Particle
+	idx             int
+	triangles       int
+	activeParticles int
+	positions       []float32
+	colors          []float32
+}
+
+// Init sets up the pool for particles in space. Particles added outside
+// of width x height are dropped.
+func (pp *ParticlePool) Init(space *Space, width, height int) {
+	pp.space = space
+	pp.width = float64(width)
+	pp.height = float64(height)
+	pp.particles = make([]Particle, MaxParticles)
+
+	for i := 0; i < MaxParticles; i++ {
+		p := Particle{}
+		pp.particles = append(pp.particles, p)
+	}
+	pp.idx = 0
+}
+
+func (pp *ParticlePool) NewParticle(p Particle) {
+	// Don't draw off-screen
+	if p.X > pp.width+30 || p.X < -30 || p.Y > pp.height+30 || p.Y < -30 {
+		return
+	}
+	pp.idx++
+	if pp.idx >= MaxParticles {
+		pp.idx = 0
+	}
+	newp := pp.particles[pp.idx]
+
+	if p.Size <= 0 {
+		p.Size = 1
+	}
+
+	newp = p
+	if p.Z == 0 {
+		newp.Z = 2
+	}
+	newp.Active = true
+	newp.Space = pp.space
+
+	pp.particles[pp.idx] = newp
+}
+
+func (pp *ParticlePool) Update(dt float64) {
+	pp.activeParticles = 0
+	pp.positions = []float32{}
+	pp.colors = []float32{}
+
+	for i := range pp.particles {
+		if pp.particles[i].Active {
+
+			pp.particles[i].Update(dt)
+			pp.activeParticles++
+			pp.positions = append(pp.positions, []float32{
+				float32(pp.particles[i].X),
+				float32(pp.particles[i].Y),
+				float32(pp.particles[i].Z),
+				float32(pp.particles[i].Size),
+			}...)
+			pp.colors = append(pp.colors, []float32{
+				float32(pp.particles[i].R),
+				float32(pp.particles[i].G),
+				float32(pp.particles[i].B),
+				float32(pp.particles[i].A),
+			}...)
+		}
+	}
+}
+
+// Active returns the number of active particles.
+func (pp *ParticlePool) Active() int {
+	return pp.activeParticles
+}
+
+func (pp *ParticlePool) Triangles() int {
+	return pp.triangles
+}
+
+// Positions returns x, y, z and size of every active particle.
+func (pp *ParticlePool) Positions() []float32 {
+	return pp.positions
+}
+
+// Colors returns r, g, b and a of every active particle.
+func (pp *ParticlePool) Colors() []float32 {
+	return pp.colors
+}
@@ -0,0 +1,295 @@
+// Package physics moves bodies and particles through a voxel world.
+//
+// Positions are in pixels, which are also the blocks of the world, time is
+// in seconds, velocities are in pixels per second and accelerations in
+// pixels per second squared. Forces are mass times acceleration.
+package physics
+
+import (
+	"math"
+	"math/rand"
+
+	"goo/voxel"
+)
+
+// Gravity is the acceleration bodies fall with.
+const Gravity = 400
+
+// contactGap is how far short of the world a body stops when it hits it,
+// so it is left outside of the block it hit.
+const contactGap = 0.01
+
+// Space is what bodies move in: the world they collide with, the pool
+// particles are added to and the source of all randomness.
+type Space struct {
+	World     *voxel.World
+	Particles *ParticlePool
+	Rand      *rand.Rand
+}
+
+// Phys is a body, either a point or the border pixels of a chunk, moved by
+// semi-implicit Euler integration: forces change the velocity first, and
+// the new velocity then moves the body. The body is swept along the move,
+// so it can't pass through thin walls, and stops where it first hits the
+// world, bouncing off the surface there and sliding along it.
+type Phys struct {
+	Space        *Space
+	X            float64
+	Y            float64
+	BorderPixels []float64
+	Rotation     float64
+
+	// VX and VY are the velocity.
+	VX float64
+	VY float64
+	// FX and FY are the forces added since the last update, which are
+	// applied and cleared by it.
+	FX float64
+	FY float64
+	// Mass is used to turn forces and impulses into changes of velocity,
+	// 0 counts as 1.
+	Mass float64
+	// Buoyancy is the share of gravity cancelled out: 0 falls, 1 floats and
+	// more than 1 rises, like smoke.
+	Buoyancy float64
+	// Drag is the share of the velocity lost per second.
+	Drag float64
+	// Restitution is the share of the speed into a surface kept when
+	// bouncing off it, from 0 to 1.
+	Restitution float64
+	// Friction is the share of the speed along a surface lost per second
+	// of touching it.
+	Friction float64
+	// Kinematic bodies are moved by their velocity alone, without gravity
+	// or the world getting in the way. They follow scripted paths.
+	Kinematic bool
+
+	prevX        float64
+	prevY        float64
+	Life         float64
+	Active       bool
+	KeepAlive    bool
+	hit          bool
+	ExplodeOnHit int
+
+	// The first contact of the last update and the blocks hit then.
+	contactX float64
+	contactY float64
+	contactT float64
+	hits     []int
+}
+
+// ApplyForce adds a force for the next update.
+func (p *Phys) ApplyForce(fx, fy float64) {
+	p.FX += fx
+	p.FY += fy
+}
+
+// ApplyImpulse changes the velocity at once by an impulse, mass times
+// velocity.
+func (p *Phys) ApplyImpulse(jx, jy float64) {
+	p.VX += jx / p.mass()
+	p.VY += jy / p.mass()
+}
+
+// MoveTo sets the velocity of a kinematic body so that the next update of
+// dt milliseconds moves it to x, y.
+func (p *Phys) MoveTo(x, y, dt float64) {
+	p.VX = (x - p.X) / (dt / 1000)
+	p.VY = (y - p.Y) / (dt / 1000)
+}
+
+func (p *Phys) mass() float64 {
+	if p.Mass == 0 {
+		return 1
+	}
+	return p.Mass
+}
+
+// Update moves the body dt milliseconds.
+func (p *Phys) Update(dt float64) {
+	if !p.Active {
+		return
+	}
+
+	if p.Life <= 0 && !p.KeepAlive {
+		p.Active = false
+		return
+	}
+
+	dt /= 1000
+
+	p.Life -= dt
+	p.prevX = p.X
+	p.prevY = p.Y
+	p.hit = false
+
+	if p.Kinematic {
+		p.X += p.VX * dt
+		p.Y += p.VY * dt
+		p.FX, p.FY = 0, 0
+		return
+	}
+
+	p.VX += p.FX / p.mass() * dt
+	p.VY += (p.FY/p.mass() - Gravity*(1-p.Buoyancy)) * dt
+	p.FX, p.FY = 0, 0
+	if p.Drag > 0 {
+		p.VX /= 1 + p.Drag*dt
+		p.VY /= 1 + p.Drag*dt
+	}
+
+	dx := p.VX * dt
+	dy := p.VY * dt
+
+	if p.ExplodeOnHit > 0 {
+		for i := 0; i < 2; i++ {
+			color := p.Space.Rand.Float32() * 0xFFFF
+			p.Space.Particles.NewParticle(Particle{
+				R:    color,
+				G:    color,
+				B:    color,
+				A:    color,
+				Size: float64(1 + p.Space.Rand.Intn(2)),
+				Phys: Phys{
+					X:        p.X,
+					Y:        p.Y,
+					VX:       10 - p.Space.Rand.Float64()*20,
+					VY:       10 - p.Space.Rand.Float64()*20,
+					Life:     p.Space.Rand.Float64() / 10,
+					Buoyancy: 1.2,
+					Active:   true,
+				},
+			})
+		}
+	}
+
+	t, hit := p.sweep(dx, dy)
+	if !hit {
+		p.X += dx
+		p.Y += dy
+		return
+	}
+
+	p.hit = true
+	p.contactT = t * dt
+	if p.ExplodeOnHit > 0 && len(p.BorderPixels) == 0 {
+		p.Space.World.Explode(p.hits[0], p.hits[1], p.ExplodeOnHit)
+		p.Active = false
+		return
+	}
+	p.moveTo(dx, dy, t)
+	p.bounce(dx, dy, t, dt)
+}
+
+// sweep walks the points of the body along dx, dy through the world, and
+// returns the share of the move made when the first of them hits it. The
+// contact point and the blocks hit then are kept.
+func (p *Phys) sweep(dx, dy float64) (float64, bool) {
+	p.hits = p.hits[:0]
+	if len(p.BorderPixels) == 0 {
+		t := p.sweepPoint(0, 0, dx, dy, 1)
+		return t, len(p.hits) > 0
+	}
+	t := 1.0
+	for i := 0; i < len(p.BorderPixels); i += 2 {
+		t = math.Min(t, p.sweepPoint(p.BorderPixels[i], p.BorderPixels[i+1], dx, dy, t))
+	}
+	return t, len(p.hits) > 0
+}
+
+// sweepPoint walks the point at ox, oy from the body along dx, dy and
+// returns the share of the move made when it hits the world, or t if it
+// doesn't hit it before that. A point hitting together with the first
+// adds its block to the hits.
+func (p *Phys) sweepPoint(ox, oy, dx, dy, t float64) float64 {
+	x, y := p.X+ox, p.Y+oy
+	bx, by, pt, ok := p.Space.World.Trace(x, y, x+dx, y+dy)
+	if !ok || pt > t+1e-9 {
+		return t
+	}
+	if pt < t-1e-9 || len(p.hits) == 0 {
+		p.hits = p.hits[:0]
+		p.contactX = x + dx*pt
+		p.contactY = y + dy*pt
+	}
+	p.hits = append(p.hits, bx, by)
+	return math.Min(pt, t)
+}
+
+// moveTo moves the body share t of dx, dy, stopping contactGap short.
+func (p *Phys) moveTo(dx, dy, t float64) {
+	if l := math.Hypot(dx, dy); l > 0 {
+		t = math.Max(0, t-contactGap/l)
+	}
+	p.X += dx * t
+	p.Y += dy * t
+}
+
+// normal returns the normal of the world at the blocks hit, averaged.
+func (p *Phys) normal() (float64, float64, bool) {
+	nx, ny := 0.0, 0.0
+	for i := 0; i < len(p.hits); i += 2 {
+		if bx, by, ok := p.Space.World.Normal(p.hits[i], p.hits[i+1]); ok {
+			nx += bx
+			ny += by
+		}
+	}
+	l := math.Hypot(nx, ny)
+	if l == 0 {
+		return 0, 0, false
+	}
+	return nx / l, ny / l, true
+}
+
+// bounce reflects the velocity off the surface hit share t into the move
+// dx, dy of dt seconds, keeping Restitution of the speed into it and
+// losing Friction of the speed along it, and then slides the body along
+// the surface for the rest of the move, as far as it can.
+func (p *Phys) bounce(dx, dy, t, dt float64) {
+	nx, ny, ok := p.normal()
+	if !ok {
+		// Inside the world, or in a gap as wide on every side: back out.
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			return
+		}
+		nx, ny = -dx/l, -dy/l
+	}
+
+	vn := p.VX*nx + p.VY*ny
+	if vn >= 0 {
+		return
+	}
+	tx, ty := p.VX-vn*nx, p.VY-vn*ny
+	keep := 1 / (1 + p.Friction*dt)
+	m := p.mass()
+	p.ApplyImpulse((tx*keep-p.Restitution*vn*nx-p.VX)*m, (ty*keep-p.Restitution*vn*ny-p.VY)*m)
+
+	dx, dy = dx*(1-t), dy*(1-t)
+	dn := dx*nx + dy*ny
+	sx, sy := (dx-dn*nx)*keep, (dy-dn*ny)*keep
+	if sx == 0 && sy == 0 {
+		return
+	}
+	// Sliding into the world again doesn't change the first contact.
+	cx, cy, ct := p.contactX, p.contactY, p.contactT
+	if st, hit := p.sweep(sx, sy); hit {
+		p.moveTo(sx, sy, st)
+	} else {
+		p.X += sx
+		p.Y += sy
+	}
+	p.contactX, p.contactY, p.contactT = cx, cy, ct
+}
+
+// Contact returns where the last update first hit the world, and how many
+// seconds into the update it did.
+func (p *Phys) Contact() (float64, float64, float64) {
+	return p.contactX, p.contactY, p.contactT
+}
+
+// Collided returns true if the last update hit the world.
+func (p *Phys) Collided() bool {
+	return p.hit
+}
@@ -0,0 +1,245 @@
+package physics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"goo/voxel"
+)
+
+// stepMs is the simulation step of the game.
+const stepMs = 1000.0 / 60
+
+// newSpace returns a 256x256 space with the blocks solid is true for.
+func newSpace(solid func(x, y int) bool) *Space {
+	w := &voxel.World{}
+	w.Init(256, 256)
+	for x := 0; x < w.Width(); x++ {
+		for y := 0; y < w.Height(); y++ {
+			if solid != nil && solid(x, y) {
+				w.Add(x, y, 1, 1, 1, 1)
+			}
+		}
+	}
+	return &Space{World: w, Rand: rand.New(rand.NewSource(1))}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestUpdateIntegrates(t *testing.T) {
+	h := stepMs / 1000
+	const steps = 30
+
+	tests := []struct {
+		name   string
+		body   Phys
+		force  [2]float64
+		vx, vy float64
+		x, y   float64
+	}{
+		{
+			name: "free fall",
+			body: Phys{X: 100, Y: 200},
+			vx:   0,
+			vy:   -Gravity * h * steps,
+			x:    100,
+			y:    200 - Gravity*h*h*steps*(steps+1)/2,
+		},
+		{
+			name: "floats",
+			body: Phys{X: 100, Y: 200, VX: 60, Buoyancy: 1},
+			vx:   60,
+			vy:   0,
+			x:    100 + 60*h*steps,
+			y:    200,
+		},
+		{
+			name:  "force over mass",
+			body:  Phys{X: 10, Y: 200, Mass: 2, Buoyancy: 1},
+			force: [2]float64{120, 0},
+			vx:    60 * h * steps,
+			vy:    0,
+			x:     10 + 60*h*h*steps*(steps+1)/2,
+			y:     200,
+		},
+		{
+			name: "drag",
+			body: Phys{X: 10, Y: 200, VX: 100, Buoyancy: 1, Drag: 2},
+			vx:   100 / math.Pow(1+2*h, steps),
+			vy:   0,
+			x:    10 + 100*h*(1-math.Pow(1+2*h, -steps))/(2*h),
+			y:    200,
+		},
+		{
+			name: "kinematic",
+			body: Phys{X: 100, Y: 200, VX: 30, VY: -30, Kinematic: true},
+			vx:   30,
+			vy:   -30,
+			x:    100 + 30*h*steps,
+			y:    200 - 30*h*steps,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.body
+			p.Space = newSpace(nil)
+			p.Active = true
+			p.KeepAlive = true
+			for i := 0; i < steps; i++ {
+				p.ApplyForce(tt.force[0], tt.force[1])
+				p.Update(stepMs)
+			}
+			if !near(p.VX, tt.vx) || !near(p.VY, tt.vy) {
+				t.Errorf("velocity = %v, %v, want %v, %v", p.VX, p.VY, tt.vx, tt.vy)
+			}
+			if !near(p.X, tt.x) || !near(p.Y, tt.y) {
+				t.Errorf("position = %v, %v, want %v, %v", p.X, p.Y, tt.x, tt.y)
+			}
+			if p.Collided() {
+				t.Errorf("collided in an empty world")
+			}
+		})
+	}
+}
+
+func TestApplyImpulse(t *testing.T) {
+	p := Phys{Mass: 4, VX: 1}
+	p.ApplyImpulse(8, -4)
+	if p.VX != 3 || p.VY != -1 {
+		t.Errorf("velocity = %v, %v, want 3, -1", p.VX, p.VY)
+	}
+}
+
+func floor(x, y int) bool {
+	return y < 50
+}
+
+func TestUpdateHits(t *testing.T) {
+	h := stepMs / 1000
+	s := math.Sqrt2
+
+	tests := []struct {
+		name  string
+		solid func(x, y int) bool
+		body  Phys
+		// The velocity after the hit, unless the surface is uneven.
+		vx, vy   float64
+		velocity bool
+		// The first contact.
+		cx, cy, ct float64
+		// Where the body ends up, or nothing for anywhere outside the
+		// world left of xMax.
+		x, y  float64
+		xMax  float64
+		where bool
+	}{
+		{
+			name:     "bounce",
+			solid:    floor,
+			body:     Phys{X: 100.5, Y: 52, VY: -300, Restitution: 0.5},
+			vx:       0,
+			vy:       150,
+			velocity: true,
+			cx:       100.5,
+			cy:       50,
+			ct:       0.4 * h,
+			x:        100.5,
+			y:        50 + contactGap,
+			where:    true,
+		},
+		{
+			name:     "stop",
+			solid:    floor,
+			body:     Phys{X: 100.5, Y: 52, VY: -300},
+			vx:       0,
+			vy:       0,
+			velocity: true,
+			cx:       100.5,
+			cy:       50,
+			ct:       0.4 * h,
+			x:        100.5,
+			y:        50 + contactGap,
+			where:    true,
+		},
+		{
+			name:     "slide at an angle",
+			solid:    floor,
+			body:     Phys{X: 100.5, Y: 52, VX: 300, VY: -300},
+			vx:       300,
+			vy:       0,
+			velocity: true,
+			cx:       102.5,
+			cy:       50,
+			ct:       0.4 * h,
+			x:        102.5 - contactGap/s + 3,
+			y:        50 + contactGap/s,
+			where:    true,
+		},
+		{
+			name:     "slide with friction",
+			solid:    floor,
+			body:     Phys{X: 100.5, Y: 52, VX: 300, VY: -300, Friction: 60},
+			vx:       150,
+			vy:       0,
+			velocity: true,
+			cx:       102.5,
+			cy:       50,
+			ct:       0.4 * h,
+			x:        102.5 - contactGap/s + 1.5,
+			y:        50 + contactGap/s,
+			where:    true,
+		},
+		{
+			name:     "slide down a slope",
+			solid:    func(x, y int) bool { return y < x },
+			body:     Phys{X: 100.25, Y: 104.75, VY: -600},
+			vx:       -300,
+			vy:       -300,
+			velocity: true,
+			cx:       100.25,
+			cy:       100,
+			ct:       0.475 * h,
+			xMax:     100.25,
+		},
+		{
+			name:  "slide into a wall",
+			solid: func(x, y int) bool { return y < 50 || x >= 104 },
+			body:  Phys{X: 100.5, Y: 52, VX: 300, VY: -300},
+			cx:    102.5,
+			cy:    50,
+			ct:    0.4 * h,
+			xMax:  104,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.body
+			p.Space = newSpace(tt.solid)
+			p.Active = true
+			p.KeepAlive = true
+			p.Buoyancy = 1
+			p.Update(stepMs)
+			if !p.Collided() {
+				t.Fatalf("didn't collide")
+			}
+			if cx, cy, ct := p.Contact(); !near(cx, tt.cx) || !near(cy, tt.cy) || !near(ct, tt.ct) {
+				t.Errorf("contact = %v, %v at %v, want %v, %v at %v", cx, cy, ct, tt.cx, tt.cy, tt.ct)
+			}
+			if tt.velocity && (!near(p.VX, tt.vx) || !near(p.VY, tt.vy)) {
+				t.Errorf("velocity = %v, %v, want %v, %v", p.VX, p.VY, tt.vx, tt.vy)
+			}
+			if tt.where && (!near(p.X, tt.x) || !near(p.Y, tt.y)) {
+				t.Errorf("position = %v, %v, want %v, %v", p.X, p.Y, tt.x, tt.y)
+			}
+			if !tt.where && p.X >= tt.xMax {
+				t.Errorf("x = %v, want less than %v", p.X, tt.xMax)
+			}
+			if p.Space.World.IsActive(int(math.Floor(p.X)), int(math.Floor(p.Y))) {
+				t.Errorf("ended up inside the world at %v, %v", p.X, p.Y)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package sound loads and plays mp3 sounds.
+package sound
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
+	"github.com/faiface/beep/mp3"
+	"github.com/faiface/beep/speaker"
+
+	"goo/loader"
+)
+
+type Sound struct {
+	sounds map[string]*Snd
+}
+
+func (s *Sound) Init() {
+	s.sounds = make(map[string]*Snd)
+	sr := beep.SampleRate(48000)
+	speaker.Init(sr, sr.N(time.Second/100))
+}
+
+type Snd struct {
+	buffer *beep.Buffer
+	ctrl   *beep.Ctrl
+	vol    *effects.Volume
+	paused bool
+}
+
+// Load loads the sound in file as name. Loading a name again replaces the
+// sound and stops the old one.
+func (s *Sound) Load(file, name string) error {
+	f, err := loader.Files.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.Contains(file, "mp3") {
+		streamer, format, err := mp3.Decode(f)
+		if err != nil {
+			return fmt.Errorf("failed to load sound %s: %v", file, err)
+		}
+
+		fmt.Printf("Loading sound: %v....", name)
+		buff := beep.NewBuffer(format)
+		buff.Append(streamer)
+		streamer.Close()
+		fmt.Printf("Done.\n")
+
+		s.Stop(name)
+		s.sounds[name] = &Snd{buffer: buff}
+	}
+	return nil
+}
+
+func (s *Sound) Play(name string, vol float64) {
+	sn, ok := s.sounds[name]
+	if !ok {
+		return
+	}
+	if vol > 1 {
+		vol = 1
+	}
+	sound := sn.buffer.Streamer(0, sn.buffer.Len())
+	ctrl := &beep.Ctrl{Streamer: beep.Loop(1, sound), Paused: false}
+	volume := &effects.Volume{
+		Streamer: ctrl,
+		Base:     1,
+		Volume:   vol,
+		Silent:   false,
+	}
+	sn.ctrl = ctrl
+	sn.vol = volume
+	speaker.Play(volume)
+}
+
+func (s *Sound) Stop(name string) {
+	sn, ok := s.sounds[name]
+	if !ok || sn.ctrl == nil || sn.ctrl.Paused {
+		return
+	}
+	sn.ctrl.Paused = true
+}
+
+func (s *Sound) Volume(name string, vol float64) {
+	sn, ok := s.sounds[name]
+	if !ok || sn.vol == nil {
+		return
+	}
+	if vol > 1 {
+		vol = 1
+	}
+	speaker.Lock()
+	sn.vol.Volume = vol
+	speaker.Unlock()
+}
+
+// Pause pauses all playing sounds except the music, until Resume.
+func (s *Sound) Pause() {
+	speaker.Lock()
+	defer speaker.Unlock()
+	for name, sn := range s.sounds {
+		if name == "bgmusic" || sn.ctrl == nil || sn.ctrl.Paused {
+			continue
+		}
+		sn.ctrl.Paused = true
+		sn.paused = true
+	}
+}
+
+// Resume plays the sounds paused by Pause again.
+func (s *Sound) Resume() {
+	speaker.Lock()
+	defer speaker.Unlock()
+	for _, sn := range s.sounds {
+		if sn.paused {
+			sn.ctrl.Paused = false
+			sn.paused = false
+		}
+	}
+}
+
+// StopAll stops all sounds except the music.
+func (s *Sound) StopAll() {
+	speaker.Lock()
+	defer speaker.Unlock()
+	for name, sn := range s.sounds {
+		if name == "bgmusic" || sn.ctrl == nil {
+			continue
+		}
+		sn.ctrl.Paused = true
+		sn.paused = false
+	}
+}
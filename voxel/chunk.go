@@ -0,0 +1,287 @@
+package voxel
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Renderer draws chunks and releases what was used to draw them.
+type Renderer interface {
+	DrawChunk(c *Chunk)
+	ReleaseChunk(c *Chunk)
+}
+
+// Chunk is a grid of blocks placed at X, Y, Z. Chunks that are not
+// static can be scaled and rotated.
+type Chunk struct {
+	RotationDeg  float32
+	Scale        float32
+	SizeX        int
+	SizeY        int
+	X            float64
+	Y            float64
+	Z            float64
+	vertices     []float32
+	indices      []uint32
+	version      int
+	dirty        bool
+	blocks       [][]Block
+	triangles    int
+	activeBlocks int
+	static       bool
+}
+
+type Block struct {
+	R     float32
+	G     float32
+	B     float32
+	A     float32
+	Used  bool
+	bType int
+	drawn bool
+}
+
+func (c *Chunk) Init(sizex, sizey int, posx, posy, posz float64, static bool) {
+	c.static = static
+	c.X = posx
+	c.Y = posy
+	c.Z = posz
+	c.SizeX = sizex
+	c.SizeY = sizey
+	c.Scale = 1.0
+
+	c.blocks = make([][]Block, sizex)
+	for i := 0; i < sizex; i++ {
+		c.blocks[i] = make([]Block, sizey)
+	}
+
+	for x := 0; x < c.SizeX; x++ {
+		for y := 0; y < c.SizeY; y++ {
+			c.blocks[x][y] = Block{}
+		}
+	}
+}
+
+func (c *Chunk) Clear(r Renderer) {
+	r.ReleaseChunk(c)
+}
+
+func (c *Chunk) Draw(r Renderer) {
+	r.DrawChunk(c)
+}
+
+// Version is bumped every time the mesh is rebuilt.
+func (c *Chunk) Version() int {
+	return c.version
+}
+
+// Model returns the model matrix used to place the chunk in the world.
+func (c *Chunk) Model() mgl32.Mat4 {
+	translate := mgl32.Translate3D(float32(c.X), float32(c.Y), float32(c.Z))
+	scale := mgl32.Scale3D(c.Scale, c.Scale, c.Scale)
+
+	if !c.static {
+		rot := mgl32.HomogRotate3D(float32(mgl32.DegToRad(float32(c.RotationDeg))), mgl32.Vec3{0.0, 0.0, 1.0})
+		return translate.Mul4(scale).Mul4(rot)
+	}
+	return translate.Mul4(scale)
+}
+
+// Mesh returns the vertices (x, y, z, r, g, b, a) and indices of the
+// chunk, rebuilding them first if any block has changed.
+func (c *Chunk) Mesh() ([]float32, []uint32) {
+	if c.dirty {
+		c.Update()
+	}
+	return c.vertices, c.indices
+}
+
+func (c *Chunk) Update() {
+	c.triangles = 0
+	c.activeBlocks = 0
+	c.vertices = []float32{}
+	c.indices = []uint32{}
+
+	// Clear drawn
+	for x := 0; x < c.SizeX; x++ {
+		for y := 0; y < c.SizeY; y++ {
+			c.blocks[x][y].drawn = false
+		}
+	}
+
+	px := 1
+	py := 1
+	max := 0
+	tx := 0
+	tmp := 0
+	maxX := 0
+	r := float32(0)
+	g := float32(0)
+	b := float32(0)
+	a := float32(0)
+
+	for xx := 0; xx < c.SizeX; xx++ {
+		for yy := 0; yy < c.SizeY; yy++ {
+			if !c.blocks[xx][yy].Used || c.blocks[xx][yy].drawn {
+				continue
+			}
+
+			r = c.blocks[xx][yy].R
+			g = c.blocks[xx][yy].G
+			b = c.blocks[xx][yy].B
+			a = c.blocks[xx][yy].A
+			px = 1
+			py = 1
+			max = 0
+			maxX = 0
+			for y := 0; y < c.SizeY-yy; y++ {
+				tx = 0
+				if maxX == 0 {
+					maxX = c.SizeX - xx
+				}
+				for x := 0; x < maxX; x++ {
+					if !c.blocks[xx+x][yy+y].Used ||
+						c.blocks[xx+x][yy+y].drawn ||
+						c.blocks[xx+x][yy+y].R != r ||
+						c.blocks[xx+x][yy+y].G != g ||
+						c.blocks[xx+x][yy+y].B != b ||
+						c.blocks[xx+x][yy+y].A != a {
+						maxX = x - 1
+						break
+					}
+					tx++
+				}
+
+				tmp = tx * (1 + y)
+				if tmp > max {
+					max = tmp
+					px = tx
+					py = y + 1
+				}
+			}
+
+			for i := xx; i < xx+px; i++ {
+				for j := yy; j < yy+py; j++ {
+					c.activeBlocks++
+					c.blocks[i][j].drawn = true
+				}
+			}
+
+			x1 := float32(xx)
+			x2 := float32(xx + px*blockSize)
+			y1 := float32(yy)
+			y2 := float32(yy + py*blockSize)
+
+			c.triangles += 2
+			c.vertices = append(c.vertices, []float32{
+				x1, y1, 0,
+				c.blocks[xx][yy].R,
+				c.blocks[xx][yy].G,
+				c.blocks[xx][yy].B,
+				c.blocks[xx][yy].A,
+				x2, y1, 0,
+				c.blocks[xx][yy].R,
+				c.blocks[xx][yy].G,
+				c.blocks[xx][yy].B,
+				c.blocks[xx][yy].A,
+				x1, y2, 0,
+				c.blocks[xx][yy].R,
+				c.blocks[xx][yy].G,
+				c.blocks[xx][yy].B,
+				c.blocks[xx][yy].A,
+				x2, y2, 0,
+				c.blocks[xx][yy].R,
+				c.blocks[xx][yy].G,
+				c.blocks[xx][yy].B,
+				c.blocks[xx][yy].A,
+			}...)
+
+			l := uint32(len(c.indices))
+			if l != 0 {
+				l -= uint32(2 * len(c.indices) / 6)
+			}
+			c.indices = append(c.indices, []uint32{
+				l, l + 1, l + 2,
+				l + 2, l + 1, l + 3,
+			}...)
+		}
+	}
+
+	c.dirty = false
+	c.version++
+}
+
+func (c *Chunk) Add(x, y int, r, g, b, a float32) {
+	if x < 0 || y < 0 || x > ChunkSize || y > ChunkSize || a == 0 {
+		return
+	}
+	c.blocks[x][y].R = r
+	c.blocks[x][y].G = g
+	c.blocks[x][y].B = b
+	c.blocks[x][y].A = a
+	c.blocks[x][y].Used = true
+	c.dirty = true
+}
+
+func (c *Chunk) Remove(x, y int, world bool) {
+	if world {
+		x = x - int(c.X)
+		y = y - int(c.Y)
+	}
+	if x < 0 || y < 0 || x >= c.SizeX || y >= c.SizeY {
+		return
+	}
+	c.blocks[x][y].Used = false
+	c.dirty = true
+}
+
+func (c *Chunk) GetBlock(x, y int, world bool) Block {
+	if world {
+		x = x - int(c.X)
+		y = y - int(c.Y)
+	}
+
+	if x < 0 || y < 0 || x >= c.SizeX || y >= c.SizeY {
+		return Block{}
+	}
+	return c.blocks[x][y]
+}
+
+func (c *Chunk) IsActive(x, y int, world bool) bool {
+	if world {
+		x = x - int(c.X)
+		y = y - int(c.Y)
+	}
+
+	if x < 0 || y < 0 || x >= c.SizeX || y >= c.SizeY {
+		return false
+	}
+	return c.blocks[x][y].Used
+}
+
+func (c *Chunk) GetBorderPixels() []float64 {
+	pixels := []float64{}
+
+	for xx := 0; xx < c.SizeX; xx++ {
+		for yy := 0; yy < c.SizeY; yy++ {
+			if !c.blocks[xx][yy].Used {
+				continue
+			}
+
+			adj := 0
+			for x := 0; x <= 1; x++ {
+				for y := 0; y <= 1; y++ {
+					if xx+x < c.SizeX && yy+y < c.SizeY && xx-x >= 0 && yy-y >= 0 {
+						if !c.blocks[xx+x][yy+y].Used || !c.blocks[xx-x][yy-y].Used {
+							adj++
+						}
+					}
+				}
+			}
+			if adj >= 1 {
+				pixels = append(pixels, []float64{float64(xx), float64(yy)}...)
+			}
+		}
+	}
+
+	return pixels
+}
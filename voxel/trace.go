@@ -0,0 +1,56 @@
+package voxel
+
+import (
+	"math"
+)
+
+// Trace walks the blocks on the line from x0, y0 to x1, y1 in order and
+// returns the first one solid is true for, with the share of the line
+// walked when entering it, from 0 to 1. The block the line starts in is
+// skipped, so a line can leave a block it is stuck in.
+func Trace(x0, y0, x1, y1 float64, solid func(x, y int) bool) (int, int, float64, bool) {
+	x, y := int(math.Floor(x0)), int(math.Floor(y0))
+	ex, ey := int(math.Floor(x1)), int(math.Floor(y1))
+	if x == ex && y == ey {
+		return 0, 0, 1, false
+	}
+	stepX, nextX, deltaX := traceAxis(x0, x1-x0)
+	stepY, nextY, deltaY := traceAxis(y0, y1-y0)
+
+	for x != ex || y != ey {
+		t := 0.0
+		if nextX < nextY {
+			t = nextX
+			nextX += deltaX
+			x += stepX
+		} else {
+			t = nextY
+			nextY += deltaY
+			y += stepY
+		}
+		if t > 1 {
+			break
+		}
+		if solid(x, y) {
+			return x, y, t, true
+		}
+	}
+	return 0, 0, 1, false
+}
+
+// traceAxis returns the step along an axis, the share of the move d from p
+// to the first block edge and the share between edges.
+func traceAxis(p, d float64) (int, float64, float64) {
+	switch {
+	case d > 0:
+		return 1, (math.Floor(p) + 1 - p) / d, 1 / d
+	case d < 0:
+		return -1, (p - math.Floor(p)) / -d, -1 / d
+	}
+	return 0, math.Inf(1), math.Inf(1)
+}
+
+// Trace is Trace through the active blocks of the world.
+func (w *World) Trace(x0, y0, x1, y1 float64) (int, int, float64, bool) {
+	return Trace(x0, y0, x1, y1, w.IsActive)
+}
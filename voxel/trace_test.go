@@ -0,0 +1,40 @@
+package voxel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	wall := func(x, y int) bool { return x >= 10 }
+	floor := func(x, y int) bool { return y < 0 }
+	block := func(x, y int) bool { return x == 5 && y == 5 }
+
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		solid          func(x, y int) bool
+		bx, by         int
+		t              float64
+		ok             bool
+	}{
+		{"right into a wall", 8.5, 3.5, 12.5, 3.5, wall, 10, 3, 0.375, true},
+		{"short of a wall", 8.5, 3.5, 9.5, 3.5, wall, 0, 0, 1, false},
+		{"down onto a floor", 3.5, 2, 3.5, -2, floor, 3, -1, 0.5, true},
+		{"onto a floor at an angle", 100.5, 2, 105.5, -3, floor, 102, -1, 0.4, true},
+		{"diagonal into a block", 2.5, 2.5, 7.5, 7.5, block, 5, 5, 0.5, true},
+		{"past a block", 2.5, 3.5, 7.5, 8.5, block, 0, 0, 1, false},
+		{"backwards into a block", 7.5, 5.5, 2.5, 5.5, block, 5, 5, 0.3, true},
+		{"out of a wall", 10.5, 3.5, 30.5, 3.5, wall, 11, 3, 0.025, true},
+		{"within a block", 10.1, 3.1, 10.9, 3.9, wall, 0, 0, 1, false},
+		{"standing still", 8.5, 3.5, 8.5, 3.5, wall, 0, 0, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bx, by, pt, ok := Trace(tt.x0, tt.y0, tt.x1, tt.y1, tt.solid)
+			if bx != tt.bx || by != tt.by || math.Abs(pt-tt.t) > 1e-9 || ok != tt.ok {
+				t.Errorf("Trace = %v, %v, %v, %v, want %v, %v, %v, %v", bx, by, pt, ok, tt.bx, tt.by, tt.t, tt.ok)
+			}
+		})
+	}
+}
@@ -1,18 +1,21 @@
-package main
+// Package voxel is a 2D world of colored blocks, kept in chunks that are
+// meshed for drawing and used for collisions.
+package voxel
 
-import ()
+import (
+	"math"
+)
 
 const (
-	chunkSize = 128
+	// ChunkSize is the width and height of the chunks of a World.
+	ChunkSize = 128
 	blockSize = 1
-	gravity   = 9.82
 )
 
 type World struct {
 	cx          int
 	cy          int
 	chunks      [][]*Chunk
-	shader      *Shader
 	totalBlocks int
 	triangles   int
 	totalChunks int
@@ -20,8 +23,8 @@ type World struct {
 
 // Init
 func (w *World) Init(sizex, sizey int) {
-	w.cx = int(sizex / chunkSize)
-	w.cy = int(sizey / chunkSize)
+	w.cx = int(sizex / ChunkSize)
+	w.cy = int(sizey / ChunkSize)
 
 	w.chunks = make([][]*Chunk, w.cx)
 	for i := 0; i < w.cx; i++ {
@@ -31,9 +34,9 @@ func (w *World) Init(sizex, sizey int) {
 	for x := 0; x < w.cx; x++ {
 		for y := 0; y < w.cy; y++ {
 			w.totalChunks++
-			w.chunks[x][y] = &Chunk{shader: w.shader}
-			w.chunks[x][y].Init(chunkSize, chunkSize, float64(x*chunkSize), float64(y*chunkSize), 0, true)
-			w.totalBlocks += chunkSize * chunkSize
+			w.chunks[x][y] = &Chunk{}
+			w.chunks[x][y].Init(ChunkSize, ChunkSize, float64(x*ChunkSize), float64(y*ChunkSize), 0, true)
+			w.totalBlocks += ChunkSize * ChunkSize
 		}
 	}
 }
@@ -46,56 +49,100 @@ func (w *World) RedrawAll() {
 	}
 }
 
-func (w *World) Clear() {
+func (w *World) Clear(r Renderer) {
 	for x := 0; x < w.cx; x++ {
 		for y := 0; y < w.cy; y++ {
-			w.chunks[x][y].Clear()
+			w.chunks[x][y].Clear(r)
 		}
 	}
 }
 
-func (w *World) Draw(dt float64) {
+func (w *World) Draw(r Renderer) {
 	for x := 0; x < w.cx; x++ {
 		for y := 0; y < w.cy; y++ {
-			w.chunks[x][y].Draw(dt)
+			w.chunks[x][y].Draw(r)
 		}
 	}
 }
 
 // Add
 func (w *World) Add(x, y int, r, g, b, a float32) {
-	cix := int(x / chunkSize)
-	ciy := int(y / chunkSize)
+	cix := int(x / ChunkSize)
+	ciy := int(y / ChunkSize)
 
 	if cix < 0 || ciy < 0 || cix >= len(w.chunks) || ciy >= len(w.chunks[cix]) {
 		return
 	}
 
-	w.chunks[cix][ciy].Add(x-(cix*chunkSize), y-(ciy*chunkSize), r, g, b, a)
+	w.chunks[cix][ciy].Add(x-(cix*ChunkSize), y-(ciy*ChunkSize), r, g, b, a)
 }
 
 // Remove
 func (w *World) Remove(x, y int) {
-	cix := int(x / chunkSize)
-	ciy := int(y / chunkSize)
+	cix := int(x / ChunkSize)
+	ciy := int(y / ChunkSize)
 
 	if cix < 0 || ciy < 0 || cix >= len(w.chunks) || ciy >= len(w.chunks[cix]) {
 		return
 	}
 
-	w.chunks[cix][ciy].Remove(x-(cix*chunkSize), y-(ciy*chunkSize), false)
+	w.chunks[cix][ciy].Remove(x-(cix*ChunkSize), y-(ciy*ChunkSize), false)
 }
 
 // IsWall checks if a block is active or not.
 func (w *World) IsActive(x, y int) bool {
-	cix := int(x / chunkSize)
-	ciy := int(y / chunkSize)
+	cix := int(x / ChunkSize)
+	ciy := int(y / ChunkSize)
 
 	if cix < 0 || ciy < 0 || cix >= len(w.chunks) || ciy >= len(w.chunks[cix]) {
 		return false
 	}
 
-	return w.chunks[cix][ciy].IsActive(x-(cix*chunkSize), y-(ciy*chunkSize), false)
+	return w.chunks[cix][ciy].IsActive(x-(cix*ChunkSize), y-(ciy*ChunkSize), false)
+}
+
+// normalRadius is how far around a block Normal looks, in blocks.
+const normalRadius = 3
+
+// Normal returns the unit normal of the surface at block x, y, pointing
+// away from the blocks around it, or false if there is no surface there:
+// no blocks around it, or as many on every side.
+func (w *World) Normal(x, y int) (float64, float64, bool) {
+	nx, ny := 0, 0
+	for dx := -normalRadius; dx <= normalRadius; dx++ {
+		for dy := -normalRadius; dy <= normalRadius; dy++ {
+			if dx*dx+dy*dy > normalRadius*normalRadius {
+				continue
+			}
+			if w.IsActive(x+dx, y+dy) {
+				nx -= dx
+				ny -= dy
+			}
+		}
+	}
+	if nx == 0 && ny == 0 {
+		return 0, 0, false
+	}
+	l := math.Hypot(float64(nx), float64(ny))
+	return float64(nx) / l, float64(ny) / l, true
+}
+
+// Width returns the width of the world in blocks.
+func (w *World) Width() int {
+	return w.cx * ChunkSize
+}
+
+// Height returns the height of the world in blocks.
+func (w *World) Height() int {
+	return w.cy * ChunkSize
+}
+
+func (w *World) TotalChunks() int {
+	return w.totalChunks
+}
+
+func (w *World) TotalBlocks() int {
+	return w.totalBlocks
 }
 
 func (w *World) DirtyChunks() int {
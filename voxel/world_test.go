@@ -0,0 +1,40 @@
+package voxel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormal(t *testing.T) {
+	tests := []struct {
+		name   string
+		solid  func(x, y int) bool
+		x, y   int
+		nx, ny float64
+		ok     bool
+	}{
+		{"floor", func(x, y int) bool { return y < 50 }, 100, 49, 0, 1, true},
+		{"ceiling", func(x, y int) bool { return y >= 50 }, 100, 50, 0, -1, true},
+		{"wall", func(x, y int) bool { return x >= 50 }, 50, 100, -1, 0, true},
+		{"slope", func(x, y int) bool { return y < x }, 100, 99, -math.Sqrt2 / 2, math.Sqrt2 / 2, true},
+		{"inside", func(x, y int) bool { return true }, 100, 100, 0, 0, false},
+		{"alone", func(x, y int) bool { return x == 100 && y == 100 }, 100, 100, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &World{}
+			w.Init(256, 256)
+			for x := 0; x < w.Width(); x++ {
+				for y := 0; y < w.Height(); y++ {
+					if tt.solid(x, y) {
+						w.Add(x, y, 1, 1, 1, 1)
+					}
+				}
+			}
+			nx, ny, ok := w.Normal(tt.x, tt.y)
+			if math.Abs(nx-tt.nx) > 1e-9 || math.Abs(ny-tt.ny) > 1e-9 || ok != tt.ok {
+				t.Errorf("Normal = %v, %v, %v, want %v, %v, %v", nx, ny, ok, tt.nx, tt.ny, tt.ok)
+			}
+		})
+	}
+}